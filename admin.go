@@ -0,0 +1,525 @@
+package chrono
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultUpcomingCount = 10
+	maxUpcomingCount     = 100
+)
+
+type AdminAction string
+
+const (
+	AdminActionRead       AdminAction = "read"
+	AdminActionPause      AdminAction = "pause"
+	AdminActionResume     AdminAction = "resume"
+	AdminActionCancel     AdminAction = "cancel"
+	AdminActionReschedule AdminAction = "reschedule"
+	AdminActionTrigger    AdminAction = "trigger"
+)
+
+// AdminAuthorizer decides whether the request is allowed to perform the given action.
+type AdminAuthorizer func(request *http.Request, action AdminAction) bool
+
+type AdminOption func(handler *AdminHandler)
+
+// WithAdminReadOnly rejects all the requests which would change the scheduler or its tasks.
+func WithAdminReadOnly() AdminOption {
+	return func(handler *AdminHandler) {
+		handler.readOnly = true
+	}
+}
+
+func WithAdminAuthorizer(authorizer AdminAuthorizer) AdminOption {
+	return func(handler *AdminHandler) {
+		handler.authorizer = authorizer
+	}
+}
+
+// AdminHandler exposes the tasks of a scheduler through a JSON API. The paths are relative,
+// so the handler is supposed to be mounted with http.StripPrefix.
+//
+//	GET  /scheduler                    the state of the scheduler with its queued tasks and running executions
+//	POST /scheduler/pause              pauses the scheduler
+//	POST /scheduler/resume             resumes the scheduler
+//	GET  /tasks                        the named tasks
+//	GET  /tasks/{name}                 the task with the given name
+//	GET  /tasks/{name}/upcoming        the upcoming execution times of the task, limited by the count parameter
+//	GET  /tasks/{name}/history         the last executions of the task
+//	POST /tasks/{name}/pause           pauses the task
+//	POST /tasks/{name}/resume          resumes the task
+//	POST /tasks/{name}/cancel          cancels the task
+//	POST /tasks/{name}/reschedule      reschedules the task with the cron, delay, period or start_time in the body
+//	POST /tasks/{name}/trigger         runs the task immediately without changing its schedule
+type AdminHandler struct {
+	scheduler  *SimpleTaskScheduler
+	readOnly   bool
+	authorizer AdminAuthorizer
+}
+
+func NewAdminHandler(scheduler *SimpleTaskScheduler, options ...AdminOption) *AdminHandler {
+	handler := &AdminHandler{
+		scheduler: scheduler,
+	}
+
+	for _, option := range options {
+		option(handler)
+	}
+
+	return handler
+}
+
+type adminTask struct {
+	ID              int        `json:"id"`
+	Name            string     `json:"name"`
+	TriggerType     string     `json:"trigger_type"`
+	CronExpression  string     `json:"cron_expression,omitempty"`
+	Period          string     `json:"period,omitempty"`
+	State           string     `json:"state"`
+	NextTriggerTime *time.Time `json:"next_trigger_time,omitempty"`
+}
+
+type adminExecution struct {
+	TaskID        int       `json:"task_id"`
+	TaskName      string    `json:"task_name"`
+	ScheduledTime time.Time `json:"scheduled_time"`
+	StartTime     time.Time `json:"start_time"`
+}
+
+type adminExecutionRecord struct {
+	TaskID        int        `json:"task_id"`
+	TaskName      string     `json:"task_name"`
+	ScheduledTime time.Time  `json:"scheduled_time"`
+	StartTime     *time.Time `json:"start_time,omitempty"`
+	EndTime       *time.Time `json:"end_time,omitempty"`
+	Duration      string     `json:"duration,omitempty"`
+	Outcome       string     `json:"outcome"`
+	Error         string     `json:"error,omitempty"`
+	Attempt       int        `json:"attempt,omitempty"`
+	Manual        bool       `json:"manual"`
+}
+
+type adminScheduler struct {
+	Paused            bool             `json:"paused"`
+	Shutdown          bool             `json:"shutdown"`
+	QueuedTasks       []adminTask      `json:"queued_tasks"`
+	RunningExecutions []adminExecution `json:"running_executions"`
+}
+
+type adminReschedule struct {
+	Cron      string    `json:"cron"`
+	Delay     string    `json:"delay"`
+	Period    string    `json:"period"`
+	StartTime time.Time `json:"start_time"`
+}
+
+type adminError struct {
+	Error string `json:"error"`
+}
+
+func (handler *AdminHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	segments := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+
+	switch {
+	case len(segments) == 1 && segments[0] == "scheduler":
+		handler.handle(writer, request, http.MethodGet, AdminActionRead, handler.getScheduler)
+	case len(segments) == 2 && segments[0] == "scheduler" && segments[1] == "pause":
+		handler.handle(writer, request, http.MethodPost, AdminActionPause, handler.pauseScheduler)
+	case len(segments) == 2 && segments[0] == "scheduler" && segments[1] == "resume":
+		handler.handle(writer, request, http.MethodPost, AdminActionResume, handler.resumeScheduler)
+	case len(segments) == 1 && segments[0] == "tasks":
+		handler.handle(writer, request, http.MethodGet, AdminActionRead, handler.getTasks)
+	case len(segments) == 2 && segments[0] == "tasks":
+		handler.handleTask(writer, request, segments[1], http.MethodGet, AdminActionRead, handler.getTask)
+	case len(segments) == 3 && segments[0] == "tasks":
+		switch segments[2] {
+		case "upcoming":
+			handler.handleTask(writer, request, segments[1], http.MethodGet, AdminActionRead, handler.getUpcoming)
+		case "history":
+			handler.handleTask(writer, request, segments[1], http.MethodGet, AdminActionRead, handler.getHistory)
+		case "pause":
+			handler.handleTask(writer, request, segments[1], http.MethodPost, AdminActionPause, handler.pauseTask)
+		case "resume":
+			handler.handleTask(writer, request, segments[1], http.MethodPost, AdminActionResume, handler.resumeTask)
+		case "cancel":
+			handler.handleTask(writer, request, segments[1], http.MethodPost, AdminActionCancel, handler.cancelTask)
+		case "reschedule":
+			handler.handleTask(writer, request, segments[1], http.MethodPost, AdminActionReschedule, handler.rescheduleTask)
+		case "trigger":
+			handler.handleTask(writer, request, segments[1], http.MethodPost, AdminActionTrigger, handler.triggerTask)
+		default:
+			writeAdminError(writer, http.StatusNotFound, errors.New("not found"))
+		}
+	default:
+		writeAdminError(writer, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+func (handler *AdminHandler) handle(writer http.ResponseWriter, request *http.Request, method string, action AdminAction,
+	handle func(writer http.ResponseWriter, request *http.Request)) {
+
+	if request.Method != method {
+		writer.Header().Set("Allow", method)
+		writeAdminError(writer, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", request.Method))
+		return
+	}
+
+	if handler.readOnly && action != AdminActionRead {
+		writeAdminError(writer, http.StatusForbidden, errors.New("admin api is read-only"))
+		return
+	}
+
+	if handler.authorizer != nil && !handler.authorizer(request, action) {
+		writeAdminError(writer, http.StatusForbidden, fmt.Errorf("action %s is not allowed", action))
+		return
+	}
+
+	handle(writer, request)
+}
+
+func (handler *AdminHandler) handleTask(writer http.ResponseWriter, request *http.Request, name string, method string, action AdminAction,
+	handle func(writer http.ResponseWriter, request *http.Request, task *namedTask)) {
+
+	handler.handle(writer, request, method, action, func(writer http.ResponseWriter, request *http.Request) {
+		task, err := handler.scheduler.getNamedTask(name)
+
+		if err != nil {
+			writeAdminError(writer, http.StatusNotFound, err)
+			return
+		}
+
+		handle(writer, request, task)
+	})
+}
+
+func (handler *AdminHandler) getScheduler(writer http.ResponseWriter, request *http.Request) {
+	snapshot := handler.scheduler.Snapshot()
+
+	state := adminScheduler{
+		Paused:            handler.scheduler.IsPaused(),
+		Shutdown:          handler.scheduler.IsShutdown(),
+		QueuedTasks:       make([]adminTask, 0, len(snapshot.QueuedTasks)),
+		RunningExecutions: make([]adminExecution, 0, len(snapshot.RunningExecutions)),
+	}
+
+	for _, queuedTask := range snapshot.QueuedTasks {
+		nextTriggerTime := queuedTask.NextTriggerTime
+		state.QueuedTasks = append(state.QueuedTasks, adminTask{
+			ID:              queuedTask.ID,
+			Name:            queuedTask.Name,
+			TriggerType:     queuedTask.TriggerType,
+			CronExpression:  queuedTask.CronExpression,
+			Period:          formatAdminPeriod(queuedTask.Period),
+			State:           queuedTask.State.String(),
+			NextTriggerTime: &nextTriggerTime,
+		})
+	}
+
+	for _, execution := range snapshot.RunningExecutions {
+		state.RunningExecutions = append(state.RunningExecutions, adminExecution{
+			TaskID:        execution.TaskID,
+			TaskName:      execution.TaskName,
+			ScheduledTime: execution.ScheduledTime,
+			StartTime:     execution.StartTime,
+		})
+	}
+
+	writeAdminJSON(writer, http.StatusOK, state)
+}
+
+func (handler *AdminHandler) pauseScheduler(writer http.ResponseWriter, request *http.Request) {
+	handler.scheduler.Pause()
+	handler.getScheduler(writer, request)
+}
+
+func (handler *AdminHandler) resumeScheduler(writer http.ResponseWriter, request *http.Request) {
+	handler.scheduler.Resume()
+	handler.getScheduler(writer, request)
+}
+
+func (handler *AdminHandler) getTasks(writer http.ResponseWriter, request *http.Request) {
+	snapshot := handler.scheduler.Snapshot()
+	tasks := handler.scheduler.namedTasks()
+
+	result := make([]adminTask, 0, len(tasks))
+
+	for _, task := range tasks {
+		result = append(result, newAdminTask(task, snapshot))
+	}
+
+	writeAdminJSON(writer, http.StatusOK, result)
+}
+
+func (handler *AdminHandler) getTask(writer http.ResponseWriter, request *http.Request, task *namedTask) {
+	writeAdminJSON(writer, http.StatusOK, newAdminTask(task, handler.scheduler.Snapshot()))
+}
+
+func (handler *AdminHandler) getUpcoming(writer http.ResponseWriter, request *http.Request, task *namedTask) {
+	count := defaultUpcomingCount
+
+	if value := request.URL.Query().Get("count"); value != "" {
+		var err error
+		count, err = strconv.Atoi(value)
+
+		if err != nil || count <= 0 || count > maxUpcomingCount {
+			writeAdminError(writer, http.StatusBadRequest, fmt.Errorf("count must be between 1 and %d", maxUpcomingCount))
+			return
+		}
+	}
+
+	writeAdminJSON(writer, http.StatusOK, upcomingExecutionTimes(task.scheduledTask, handler.scheduler.Snapshot(), count))
+}
+
+func (handler *AdminHandler) getHistory(writer http.ResponseWriter, request *http.Request, task *namedTask) {
+	var history []ExecutionRecord
+
+	if provider, ok := task.scheduledTask.(HistoryProvider); ok {
+		history = provider.History()
+	}
+
+	records := make([]adminExecutionRecord, 0, len(history))
+
+	for _, record := range history {
+		adminRecord := adminExecutionRecord{
+			TaskID:        record.TaskID,
+			TaskName:      record.TaskName,
+			ScheduledTime: record.ScheduledTime,
+			Outcome:       record.Outcome.String(),
+			Attempt:       record.Attempt,
+			Manual:        record.Manual,
+		}
+
+		if !record.StartTime.IsZero() {
+			startTime, endTime := record.StartTime, record.EndTime
+			adminRecord.StartTime = &startTime
+			adminRecord.EndTime = &endTime
+			adminRecord.Duration = record.Duration.String()
+		}
+
+		if record.Err != nil {
+			adminRecord.Error = record.Err.Error()
+		}
+
+		records = append(records, adminRecord)
+	}
+
+	writeAdminJSON(writer, http.StatusOK, records)
+}
+
+func (handler *AdminHandler) pauseTask(writer http.ResponseWriter, request *http.Request, task *namedTask) {
+	pausable, ok := task.scheduledTask.(Pausable)
+
+	if !ok {
+		writeAdminError(writer, http.StatusConflict, errors.New("task cannot be paused"))
+		return
+	}
+
+	pausable.Pause()
+	handler.getTask(writer, request, task)
+}
+
+func (handler *AdminHandler) resumeTask(writer http.ResponseWriter, request *http.Request, task *namedTask) {
+	pausable, ok := task.scheduledTask.(Pausable)
+
+	if !ok {
+		writeAdminError(writer, http.StatusConflict, errors.New("task cannot be resumed"))
+		return
+	}
+
+	pausable.Resume()
+	handler.getTask(writer, request, task)
+}
+
+func (handler *AdminHandler) cancelTask(writer http.ResponseWriter, request *http.Request, task *namedTask) {
+	task.scheduledTask.Cancel()
+	handler.getTask(writer, request, task)
+}
+
+func (handler *AdminHandler) rescheduleTask(writer http.ResponseWriter, request *http.Request, task *namedTask) {
+	var reschedule adminReschedule
+
+	if err := json.NewDecoder(request.Body).Decode(&reschedule); err != nil {
+		writeAdminError(writer, http.StatusBadRequest, fmt.Errorf("request body is not valid : %v", err))
+		return
+	}
+
+	var options []Option
+
+	if !reschedule.StartTime.IsZero() {
+		options = append(options, WithTime(reschedule.StartTime))
+	}
+
+	name := task.schedulerTask.name
+	var err error
+
+	switch {
+	case reschedule.Cron != "":
+		err = handler.scheduler.RescheduleWithCron(name, reschedule.Cron, options...)
+	case reschedule.Delay != "":
+		var delay time.Duration
+		if delay, err = time.ParseDuration(reschedule.Delay); err == nil {
+			err = handler.scheduler.RescheduleWithFixedDelay(name, delay, options...)
+		}
+	case reschedule.Period != "":
+		var period time.Duration
+		if period, err = time.ParseDuration(reschedule.Period); err == nil {
+			err = handler.scheduler.RescheduleAtFixedRate(name, period, options...)
+		}
+	case !reschedule.StartTime.IsZero():
+		err = handler.scheduler.Reschedule(name, options...)
+	default:
+		err = errors.New("one of cron, delay, period or start_time must be given")
+	}
+
+	if err != nil {
+		writeAdminError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	handler.getTask(writer, request, task)
+}
+
+func (handler *AdminHandler) triggerTask(writer http.ResponseWriter, request *http.Request, task *namedTask) {
+	if err := handler.scheduler.TriggerNow(task.schedulerTask.name); err != nil {
+		writeAdminError(writer, http.StatusConflict, err)
+		return
+	}
+
+	writeAdminJSON(writer, http.StatusAccepted, newAdminTask(task, handler.scheduler.Snapshot()))
+}
+
+func newAdminTask(task *namedTask, snapshot ExecutorSnapshot) adminTask {
+	result := adminTask{
+		ID:    taskID(task.scheduledTask),
+		Name:  task.schedulerTask.name,
+		State: TaskStateScheduled.String(),
+	}
+
+	switch scheduledTask := task.scheduledTask.(type) {
+	case *TriggerTask:
+		scheduledTask.triggerContextMu.RLock()
+		trigger := scheduledTask.trigger
+		nextTriggerTime := scheduledTask.nextTriggerTime
+		scheduledTask.triggerContextMu.RUnlock()
+
+		result.TriggerType = TriggerTypeCustom
+
+		switch trigger := trigger.(type) {
+		case *CronTrigger:
+			result.TriggerType = TriggerTypeCron
+			result.CronExpression = trigger.Expression()
+		case *EventTrigger:
+			result.TriggerType = TriggerTypeEvent
+		}
+
+		if !nextTriggerTime.IsZero() {
+			result.NextTriggerTime = &nextTriggerTime
+		}
+	case *ScheduledRunnableTask:
+		result.TriggerType, result.CronExpression = scheduledTask.describeTrigger()
+		result.Period = formatAdminPeriod(scheduledTask.getPeriod())
+	}
+
+	for _, queuedTask := range snapshot.QueuedTasks {
+		if queuedTask.ID == result.ID && queuedTask.TriggerType != TriggerTypeManual {
+			result.State = queuedTask.State.String()
+
+			// the next trigger time of a trigger task is kept by the task itself
+			if result.NextTriggerTime == nil {
+				nextTriggerTime := queuedTask.NextTriggerTime
+				result.NextTriggerTime = &nextTriggerTime
+			}
+		}
+	}
+
+	for _, execution := range snapshot.RunningExecutions {
+		if execution.TaskID == result.ID {
+			result.State = TaskStateRunning.String()
+		}
+	}
+
+	if task.scheduledTask.IsCancelled() {
+		result.State = TaskStateCancelled.String()
+		result.NextTriggerTime = nil
+	} else if pausable, ok := task.scheduledTask.(Pausable); ok && pausable.IsPaused() {
+		result.State = TaskStatePaused.String()
+	}
+
+	return result
+}
+
+// upcomingExecutionTimes returns the next execution times of the task. The execution times of a fixed-delay
+// task are estimated, since they depend on how long the executions of the task take.
+func upcomingExecutionTimes(task ScheduledTask, snapshot ExecutorSnapshot, count int) []time.Time {
+	executionTimes := make([]time.Time, 0, count)
+
+	if task.IsCancelled() {
+		return executionTimes
+	}
+
+	var nextTriggerTime time.Time
+
+	for _, queuedTask := range snapshot.QueuedTasks {
+		if queuedTask.ID == taskID(task) && queuedTask.TriggerType != TriggerTypeManual {
+			nextTriggerTime = queuedTask.NextTriggerTime
+		}
+	}
+
+	switch scheduledTask := task.(type) {
+	case *TriggerTask:
+		scheduledTask.triggerContextMu.RLock()
+		trigger := scheduledTask.trigger
+		nextTriggerTime = scheduledTask.nextTriggerTime
+		scheduledTask.triggerContextMu.RUnlock()
+
+		cronTrigger, ok := trigger.(*CronTrigger)
+
+		for next := nextTriggerTime; !next.IsZero() && len(executionTimes) < count; {
+			executionTimes = append(executionTimes, next)
+
+			if !ok {
+				break
+			}
+
+			next = cronTrigger.NextExecutionTimeAfter(next)
+		}
+	case *ScheduledRunnableTask:
+		period := scheduledTask.getPeriod()
+
+		for next := nextTriggerTime; !next.IsZero() && len(executionTimes) < count; next = next.Add(period) {
+			executionTimes = append(executionTimes, next)
+
+			if period == 0 {
+				break
+			}
+		}
+	}
+
+	return executionTimes
+}
+
+func formatAdminPeriod(period time.Duration) string {
+	if period == 0 {
+		return ""
+	}
+
+	return period.String()
+}
+
+func writeAdminJSON(writer http.ResponseWriter, status int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	json.NewEncoder(writer).Encode(value)
+}
+
+func writeAdminError(writer http.ResponseWriter, status int, err error) {
+	writeAdminJSON(writer, status, adminError{err.Error()})
+}
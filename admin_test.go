@@ -0,0 +1,278 @@
+package chrono
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestAdminServer(t *testing.T, options ...AdminOption) (*SimpleTaskScheduler, *httptest.Server) {
+	scheduler := NewSimpleTaskScheduler(NewSimpleTaskExecutor(NewDefaultTaskRunner()))
+
+	_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+	}, "0 0 * * * *", WithName("cron-task"))
+	assert.Nil(t, err)
+
+	_, err = scheduler.ScheduleAtFixedRate(func(ctx context.Context) {
+	}, 1*time.Hour, WithName("fixed-rate-task"), WithTime(time.Now().Add(1*time.Hour)))
+	assert.Nil(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", http.StripPrefix("/admin", NewAdminHandler(scheduler, options...)))
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return scheduler, server
+}
+
+func doAdminRequest(t *testing.T, method string, url string, body string, result interface{}) int {
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	assert.Nil(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+
+	if result != nil {
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(result))
+	}
+
+	return response.StatusCode
+}
+
+func TestAdminHandler_GetTasks(t *testing.T) {
+	_, server := newTestAdminServer(t)
+
+	var tasks []adminTask
+	status := doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks", "", &tasks)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, tasks, 2)
+
+	assert.Equal(t, "cron-task", tasks[0].Name)
+	assert.Equal(t, TriggerTypeCron, tasks[0].TriggerType)
+	assert.Equal(t, "0 0 * * * *", tasks[0].CronExpression)
+	assert.Equal(t, "scheduled", tasks[0].State)
+	assert.NotNil(t, tasks[0].NextTriggerTime)
+
+	assert.Equal(t, "fixed-rate-task", tasks[1].Name)
+	assert.Equal(t, TriggerTypeFixedRate, tasks[1].TriggerType)
+	assert.Equal(t, "1h0m0s", tasks[1].Period)
+	assert.Equal(t, "scheduled", tasks[1].State)
+}
+
+func TestAdminHandler_GetTask(t *testing.T) {
+	_, server := newTestAdminServer(t)
+
+	var task adminTask
+	status := doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks/cron-task", "", &task)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "cron-task", task.Name)
+
+	var adminErr adminError
+	status = doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks/unknown-task", "", &adminErr)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "no task found with name unknown-task", adminErr.Error)
+}
+
+func TestAdminHandler_GetUpcoming(t *testing.T) {
+	_, server := newTestAdminServer(t)
+
+	var executionTimes []time.Time
+	status := doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks/cron-task/upcoming?count=3", "", &executionTimes)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, executionTimes, 3)
+	assert.Equal(t, 0, executionTimes[0].Minute())
+	assert.Equal(t, 1*time.Hour, executionTimes[1].Sub(executionTimes[0]))
+	assert.Equal(t, 1*time.Hour, executionTimes[2].Sub(executionTimes[1]))
+
+	status = doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks/fixed-rate-task/upcoming?count=2", "", &executionTimes)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, executionTimes, 2)
+	assert.Equal(t, 1*time.Hour, executionTimes[1].Sub(executionTimes[0]))
+
+	status = doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks/cron-task/upcoming?count=0", "", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestAdminHandler_PauseResumeAndCancelTask(t *testing.T) {
+	scheduler, server := newTestAdminServer(t)
+
+	var task adminTask
+	status := doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/cron-task/pause", "", &task)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "paused", task.State)
+
+	scheduledTask, _ := scheduler.GetTask("cron-task")
+	assert.True(t, scheduledTask.(Pausable).IsPaused())
+
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/cron-task/resume", "", &task)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "scheduled", task.State)
+	assert.False(t, scheduledTask.(Pausable).IsPaused())
+
+	var cancelledTask adminTask
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/cron-task/cancel", "", &cancelledTask)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "cancelled", cancelledTask.State)
+	assert.Nil(t, cancelledTask.NextTriggerTime)
+	assert.True(t, scheduledTask.IsCancelled())
+}
+
+func TestAdminHandler_RescheduleTask(t *testing.T) {
+	_, server := newTestAdminServer(t)
+
+	var task adminTask
+	status := doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/cron-task/reschedule", `{"cron": "0 30 * * * *"}`, &task)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "0 30 * * * *", task.CronExpression)
+	assert.Equal(t, 30, task.NextTriggerTime.Minute())
+
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/fixed-rate-task/reschedule", `{"period": "2h"}`, &task)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "2h0m0s", task.Period)
+
+	var adminErr adminError
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/fixed-rate-task/reschedule", `{"delay": "2h"}`, &adminErr)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "task fixed-rate-task is not a fixed-delay task", adminErr.Error)
+
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/fixed-rate-task/reschedule", `{}`, &adminErr)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/fixed-rate-task/reschedule", `invalid`, &adminErr)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestAdminHandler_Scheduler(t *testing.T) {
+	scheduler, server := newTestAdminServer(t)
+
+	var state adminScheduler
+	status := doAdminRequest(t, http.MethodPost, server.URL+"/admin/scheduler/pause", "", &state)
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, state.Paused)
+	assert.True(t, scheduler.IsPaused())
+
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/scheduler/resume", "", &state)
+	assert.Equal(t, http.StatusOK, status)
+	assert.False(t, state.Paused)
+
+	status = doAdminRequest(t, http.MethodGet, server.URL+"/admin/scheduler", "", &state)
+	assert.Equal(t, http.StatusOK, status)
+	assert.False(t, state.Shutdown)
+	assert.Len(t, state.QueuedTasks, 2)
+	assert.Equal(t, "cron-task", state.QueuedTasks[0].Name)
+	assert.Equal(t, TriggerTypeCron, state.QueuedTasks[0].TriggerType)
+	assert.Empty(t, state.RunningExecutions)
+}
+
+func TestAdminHandler_MethodNotAllowedAndNotFound(t *testing.T) {
+	_, server := newTestAdminServer(t)
+
+	status := doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks/cron-task/pause", "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+
+	status = doAdminRequest(t, http.MethodGet, server.URL+"/admin/unknown", "", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status = doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks/cron-task/unknown", "", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAdminHandler_WithAdminReadOnly(t *testing.T) {
+	scheduler, server := newTestAdminServer(t, WithAdminReadOnly())
+
+	status := doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks", "", nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	var adminErr adminError
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/cron-task/cancel", "", &adminErr)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "admin api is read-only", adminErr.Error)
+
+	scheduledTask, _ := scheduler.GetTask("cron-task")
+	assert.False(t, scheduledTask.IsCancelled())
+}
+
+func TestAdminHandler_WithAdminAuthorizer(t *testing.T) {
+	_, server := newTestAdminServer(t, WithAdminAuthorizer(func(request *http.Request, action AdminAction) bool {
+		return request.Header.Get("Authorization") == "Bearer token" || action == AdminActionRead
+	}))
+
+	status := doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks", "", nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	var adminErr adminError
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/cron-task/pause", "", &adminErr)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "action pause is not allowed", adminErr.Error)
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL+"/admin/tasks/cron-task/pause", nil)
+	request.Header.Set("Authorization", "Bearer token")
+	response, err := http.DefaultClient.Do(request)
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestAdminHandler_TriggerTask(t *testing.T) {
+	scheduler, server := newTestAdminServer(t)
+
+	var counter int32
+	_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, "0 0 0 1 1 *", WithName("yearly-task"))
+	assert.Nil(t, err)
+
+	var task adminTask
+	status := doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/yearly-task/trigger", "", &task)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, "yearly-task", task.Name)
+	assert.Equal(t, time.January, task.NextTriggerTime.Month())
+	assert.Equal(t, 1, task.NextTriggerTime.Day())
+
+	<-time.After(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+
+	scheduledTask, _ := scheduler.GetTask("yearly-task")
+	scheduledTask.Cancel()
+
+	var adminErr adminError
+	status = doAdminRequest(t, http.MethodPost, server.URL+"/admin/tasks/yearly-task/trigger", "", &adminErr)
+	assert.Equal(t, http.StatusConflict, status)
+}
+
+func TestAdminHandler_GetHistory(t *testing.T) {
+	scheduler, server := newTestAdminServer(t)
+
+	_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		execution, _ := ExecutionFromContext(ctx)
+		execution.Fail(errors.New("test error"))
+	}, "0 0 0 1 1 *", WithName("yearly-task"))
+	assert.Nil(t, err)
+
+	assert.Nil(t, scheduler.TriggerNow("yearly-task"))
+	<-time.After(100 * time.Millisecond)
+
+	var records []adminExecutionRecord
+	status := doAdminRequest(t, http.MethodGet, server.URL+"/admin/tasks/yearly-task/history", "", &records)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "yearly-task", records[0].TaskName)
+	assert.Equal(t, "failed", records[0].Outcome)
+	assert.Equal(t, "test error", records[0].Error)
+	assert.True(t, records[0].Manual)
+	assert.NotNil(t, records[0].StartTime)
+	assert.NotEmpty(t, records[0].Duration)
+}
@@ -0,0 +1,134 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type followUpCondition int
+
+const (
+	followOnSuccess followUpCondition = iota
+	followOnFailure
+	followOnComplete
+)
+
+type followUp struct {
+	condition followUpCondition
+	task      Task
+	options   []Option
+}
+
+type previousExecutionContextKey struct{}
+
+// PreviousExecution is the completed execution of a task whose follow-up task is running.
+type PreviousExecution struct {
+	TaskID        int
+	TaskName      string
+	ScheduledTime time.Time
+	Err           error
+	Result        interface{}
+}
+
+// PreviousExecutionFromContext returns the execution which has submitted the running follow-up task.
+func PreviousExecutionFromContext(ctx context.Context) (PreviousExecution, bool) {
+	if ctx == nil {
+		return PreviousExecution{}, false
+	}
+
+	previous, ok := ctx.Value(previousExecutionContextKey{}).(PreviousExecution)
+	return previous, ok
+}
+
+// OnSuccess submits the given task to the executor of the scheduler each time an execution of the task
+// succeeds. The options of the follow-up task are applied the same way as the scheduled tasks except
+// the ones related to its schedule.
+func OnSuccess(task Task, options ...Option) Option {
+	return withFollowUp(followOnSuccess, task, options)
+}
+
+// OnFailure submits the given task each time an execution of the task fails after all of its attempts.
+func OnFailure(task Task, options ...Option) Option {
+	return withFollowUp(followOnFailure, task, options)
+}
+
+// OnComplete submits the given task each time an execution of the task is completed.
+func OnComplete(task Task, options ...Option) Option {
+	return withFollowUp(followOnComplete, task, options)
+}
+
+func withFollowUp(condition followUpCondition, task Task, options []Option) Option {
+	return func(schedulerTask *SchedulerTask) error {
+		if task == nil {
+			return errors.New("follow-up task cannot be nil")
+		}
+
+		schedulerTask.followUps = append(schedulerTask.followUps, followUp{
+			condition: condition,
+			task:      task,
+			options:   options,
+		})
+		return nil
+	}
+}
+
+func (followUp followUp) matches(err error) bool {
+	switch followUp.condition {
+	case followOnSuccess:
+		return err == nil
+	case followOnFailure:
+		return err != nil
+	}
+
+	return true
+}
+
+// followUpMiddleware submits the follow-up tasks once an execution is completed. It is the outermost middleware,
+// so that the follow-up tasks are submitted after the retries.
+func followUpMiddleware(executor TaskExecutor, logger *loggerHolder, followUps []followUp) Middleware {
+	return func(next Task) Task {
+		return func(ctx context.Context) {
+			execution, ok := ExecutionFromContext(ctx)
+
+			if !ok {
+				next(ctx)
+				return
+			}
+
+			// an execution covers all the catch-up runs of a trigger task, so each run is evaluated on its own
+			previousErr := execution.startRun()
+			execution.Fail(runTask(ctx, next))
+			err := execution.endRun(previousErr)
+
+			if execution.getSkipReason() != nil {
+				return
+			}
+
+			previous := PreviousExecution{
+				TaskID:        execution.TaskID(),
+				TaskName:      execution.TaskName(),
+				ScheduledTime: execution.ScheduledTime(),
+				Err:           err,
+				Result:        execution.Result(),
+			}
+
+			for _, followUp := range followUps {
+				if !followUp.matches(previous.Err) {
+					continue
+				}
+
+				task := followUp.task
+				followUpTask := func(ctx context.Context) {
+					task(context.WithValue(ctx, previousExecutionContextKey{}, previous))
+				}
+
+				if _, err := scheduleTask(executor, followUpTask, 0, 0, false, followUp.options...); err != nil {
+					logger.get().Warn("follow-up task could not be submitted", taskLogAttrs(previous.TaskID, previous.TaskName,
+						slog.Any("error", err))...)
+				}
+			}
+		}
+	}
+}
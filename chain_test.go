@@ -0,0 +1,230 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnSuccess(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	previousExecutions := make(chan PreviousExecution, 2)
+	var failures int32
+
+	task, err := scheduler.Schedule(func(ctx context.Context) {
+		execution, _ := ExecutionFromContext(ctx)
+		execution.SetResult("report.csv")
+	}, WithName("export"),
+		OnSuccess(func(ctx context.Context) {
+			previous, ok := PreviousExecutionFromContext(ctx)
+			assert.True(t, ok)
+			previousExecutions <- previous
+		}, WithName("upload")),
+		OnFailure(func(ctx context.Context) {
+			atomic.AddInt32(&failures, 1)
+		}),
+	)
+	assert.Nil(t, err)
+
+	select {
+	case previous := <-previousExecutions:
+		assert.Equal(t, task.(Identifiable).ID(), previous.TaskID)
+		assert.Equal(t, "export", previous.TaskName)
+		assert.Equal(t, "report.csv", previous.Result)
+		assert.Nil(t, previous.Err)
+	case <-time.After(time.Second):
+		t.Fatal("follow-up task has not been run")
+	}
+
+	<-time.After(100 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&failures))
+}
+
+func TestOnFailure(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	failure := errors.New("disk is full")
+	previousExecutions := make(chan PreviousExecution, 2)
+	var attempts, successes int32
+
+	_, err := scheduler.Schedule(func(ctx context.Context) {
+		atomic.AddInt32(&attempts, 1)
+		execution, _ := ExecutionFromContext(ctx)
+		execution.Fail(failure)
+	}, WithRetry(3, 10*time.Millisecond),
+		OnSuccess(func(ctx context.Context) {
+			atomic.AddInt32(&successes, 1)
+		}),
+		OnFailure(func(ctx context.Context) {
+			previous, _ := PreviousExecutionFromContext(ctx)
+			previousExecutions <- previous
+		}),
+	)
+	assert.Nil(t, err)
+
+	select {
+	case previous := <-previousExecutions:
+		assert.Equal(t, failure, previous.Err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	case <-time.After(time.Second):
+		t.Fatal("follow-up task has not been run")
+	}
+
+	<-time.After(100 * time.Millisecond)
+	assert.Len(t, previousExecutions, 0)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&successes))
+}
+
+func TestOnFailure_MisfirePolicyRunAll(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	var runs, successes, failures int32
+
+	task, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		if atomic.AddInt32(&runs, 1) == 1 {
+			execution, _ := ExecutionFromContext(ctx)
+			execution.Fail(errors.New("disk is full"))
+		}
+	}, "* * * * * *", WithMisfirePolicy(MisfirePolicyRunAll),
+		OnSuccess(func(ctx context.Context) {
+			atomic.AddInt32(&successes, 1)
+		}),
+		OnFailure(func(ctx context.Context) {
+			atomic.AddInt32(&failures, 1)
+		}),
+	)
+	assert.Nil(t, err)
+
+	task.(Pausable).Pause()
+	<-time.After(2500 * time.Millisecond)
+	task.(Pausable).Resume()
+
+	<-time.After(200 * time.Millisecond)
+	task.Cancel()
+	<-time.After(200 * time.Millisecond)
+
+	assert.True(t, atomic.LoadInt32(&runs) >= 2, "catch-up runs must be executed, actual: %d", runs)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&failures))
+	assert.Equal(t, atomic.LoadInt32(&runs)-1, atomic.LoadInt32(&successes))
+}
+
+func TestOnFailure_Panic(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	errs := make(chan error, 1)
+
+	_, err := scheduler.Schedule(func(ctx context.Context) {
+		panic("unexpected")
+	}, OnFailure(func(ctx context.Context) {
+		previous, _ := PreviousExecutionFromContext(ctx)
+		errs <- previous.Err
+	}))
+	assert.Nil(t, err)
+
+	select {
+	case err = <-errs:
+		assert.EqualError(t, err, "task panicked : unexpected")
+	case <-time.After(time.Second):
+		t.Fatal("follow-up task has not been run")
+	}
+}
+
+func TestOnComplete(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	var executions, completions int32
+
+	task, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {
+		if atomic.AddInt32(&executions, 1)%2 == 0 {
+			execution, _ := ExecutionFromContext(ctx)
+			execution.Fail(errors.New("failed"))
+		}
+	}, 50*time.Millisecond, OnComplete(func(ctx context.Context) {
+		atomic.AddInt32(&completions, 1)
+	}))
+	assert.Nil(t, err)
+
+	<-time.After(220 * time.Millisecond)
+	task.Cancel()
+	<-time.After(100 * time.Millisecond)
+
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&executions), int32(4))
+	assert.Equal(t, atomic.LoadInt32(&executions), atomic.LoadInt32(&completions))
+}
+
+func TestOnSuccess_Chain(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	results := make(chan interface{}, 1)
+
+	_, err := scheduler.Schedule(func(ctx context.Context) {
+		execution, _ := ExecutionFromContext(ctx)
+		execution.SetResult(1)
+	}, OnSuccess(func(ctx context.Context) {
+		previous, _ := PreviousExecutionFromContext(ctx)
+		execution, _ := ExecutionFromContext(ctx)
+		execution.SetResult(previous.Result.(int) + 1)
+	}, OnSuccess(func(ctx context.Context) {
+		previous, _ := PreviousExecutionFromContext(ctx)
+		results <- previous.Result
+	})))
+	assert.Nil(t, err)
+
+	select {
+	case result := <-results:
+		assert.Equal(t, 2, result)
+	case <-time.After(time.Second):
+		t.Fatal("follow-up task has not been run")
+	}
+}
+
+func TestOnFailure_Timeout(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	errs := make(chan error, 1)
+
+	_, err := scheduler.Schedule(func(ctx context.Context) {
+		<-ctx.Done()
+	}, WithTimeout(50*time.Millisecond), OnFailure(func(ctx context.Context) {
+		previous, _ := PreviousExecutionFromContext(ctx)
+		errs <- previous.Err
+	}, WithTimeout(time.Second)))
+	assert.Nil(t, err)
+
+	select {
+	case err = <-errs:
+		assert.Equal(t, ErrExecutionTimeout, err)
+	case <-time.After(time.Second):
+		t.Fatal("follow-up task has not been run")
+	}
+}
+
+func TestOnSuccess_InvalidTask(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	_, err := scheduler.Schedule(func(ctx context.Context) {}, OnSuccess(nil))
+	assert.EqualError(t, err, "follow-up task cannot be nil")
+
+	_, err = scheduler.Schedule(func(ctx context.Context) {}, OnFailure(func(ctx context.Context) {}, WithTimeout(0)))
+	assert.EqualError(t, err, "timeout must be greater than zero")
+}
+
+func TestPreviousExecutionFromContext(t *testing.T) {
+	_, ok := PreviousExecutionFromContext(context.Background())
+	assert.False(t, ok)
+
+	_, ok = PreviousExecutionFromContext(nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,141 @@
+package chronoconfig
+
+import (
+	"bytes"
+	"codnect.io/chrono"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatYAML
+	FormatTOML
+)
+
+func (format Format) String() string {
+	switch format {
+	case FormatJSON:
+		return "json"
+	case FormatYAML:
+		return "yaml"
+	case FormatTOML:
+		return "toml"
+	}
+
+	return "unknown"
+}
+
+// FormatOf returns the format of a config file by its extension.
+func FormatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".toml":
+		return FormatTOML, nil
+	}
+
+	return 0, fmt.Errorf("unsupported config file : %s", path)
+}
+
+// LoadFile reads the job definitions from the given config file, whose format is
+// determined by its extension.
+func LoadFile(path string) ([]chrono.JobDefinition, error) {
+	format, err := FormatOf(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data, format)
+}
+
+// Parse parses the job definitions defined under the jobs key in the given format.
+func Parse(data []byte, format Format) ([]chrono.JobDefinition, error) {
+	var definitions chrono.JobDefinitions
+
+	if err := Unmarshal(data, format, &definitions); err != nil {
+		return nil, err
+	}
+
+	return definitions.Jobs, nil
+}
+
+// Unmarshal decodes the config in the given format into the value pointed by v, which makes it possible to
+// define the jobs in a config with other keys. The keys not known by the value are reported as errors.
+func Unmarshal(data []byte, format Format, v interface{}) error {
+	switch format {
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(v)
+	case FormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		return nil
+	case FormatTOML:
+		metadata, err := toml.Decode(string(data), v)
+
+		if err != nil {
+			return err
+		}
+
+		if undecoded := metadata.Undecoded(); len(undecoded) != 0 {
+			return fmt.Errorf("unknown config key : %s", undecoded[0])
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown config format : %d", format)
+}
+
+// ScheduleFile schedules the jobs defined in the given config file on the scheduler.
+// The errors of the jobs which cannot be scheduled are returned altogether.
+func ScheduleFile(scheduler *chrono.SimpleTaskScheduler, path string) error {
+	definitions, err := LoadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	return scheduler.ScheduleJobDefinitions(definitions)
+}
+
+// NewFileWatcher returns a watcher reconciling the jobs with the given config file, whose format is
+// determined by its extension.
+func NewFileWatcher(reconciler *chrono.JobReconciler, path string, options ...chrono.FileWatcherOption) (*chrono.FileWatcher, error) {
+	format, err := FormatOf(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parser := chrono.WithDefinitionParser(func(data []byte) ([]chrono.JobDefinition, error) {
+		return Parse(data, format)
+	})
+
+	return chrono.NewFileWatcher(reconciler, path, append([]chrono.FileWatcherOption{parser}, options...)...)
+}
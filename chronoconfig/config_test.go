@@ -0,0 +1,234 @@
+package chronoconfig
+
+import (
+	"codnect.io/chrono"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const yamlConfig = `
+jobs:
+  - name: report
+    task: send-report
+    cron: "0 0 9 * * *"
+    location: UTC
+    timeout: 5m
+    retry:
+      max_attempts: 3
+      delay: 30s
+    concurrency_policy: forbid
+  - name: cleanup
+    task: cleanup
+    fixed_delay: 1h
+    start_time: 2024-01-01T00:00:00Z
+    enabled: false
+`
+
+const tomlConfig = `
+[[jobs]]
+name = "report"
+task = "send-report"
+cron = "0 0 9 * * *"
+location = "UTC"
+timeout = "5m"
+concurrency_policy = "forbid"
+
+[jobs.retry]
+max_attempts = 3
+delay = "30s"
+
+[[jobs]]
+name = "cleanup"
+task = "cleanup"
+fixed_delay = "1h"
+start_time = 2024-01-01T00:00:00Z
+enabled = false
+`
+
+const jsonConfig = `{
+  "jobs": [
+    {
+      "name": "report",
+      "task": "send-report",
+      "cron": "0 0 9 * * *",
+      "location": "UTC",
+      "timeout": "5m",
+      "retry": {"max_attempts": 3, "delay": "30s"},
+      "concurrency_policy": "forbid"
+    },
+    {
+      "name": "cleanup",
+      "task": "cleanup",
+      "fixed_delay": "1h",
+      "start_time": "2024-01-01T00:00:00Z",
+      "enabled": false
+    }
+  ]
+}`
+
+func assertDefinitions(t *testing.T, definitions []chrono.JobDefinition) {
+	assert.Len(t, definitions, 2)
+
+	job, err := definitions[0].Job()
+	assert.Nil(t, err)
+	assert.Equal(t, chrono.Job{
+		Name:              "report",
+		TaskName:          "send-report",
+		Cron:              "0 0 9 * * *",
+		Location:          "UTC",
+		ConcurrencyPolicy: chrono.ConcurrencyPolicyForbid,
+		Timeout:           5 * time.Minute,
+		RetryAttempts:     3,
+		RetryDelay:        30 * time.Second,
+	}, job)
+
+	assert.False(t, definitions[1].IsEnabled())
+	job, err = definitions[1].Job()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, job.FixedDelay)
+	assert.True(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Equal(job.StartTime))
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		format Format
+		data   string
+	}{
+		{FormatJSON, jsonConfig},
+		{FormatYAML, yamlConfig},
+		{FormatTOML, tomlConfig},
+	}
+
+	for _, testCase := range testCases {
+		definitions, err := Parse([]byte(testCase.data), testCase.format)
+		assert.Nil(t, err, testCase.format.String())
+		assertDefinitions(t, definitions)
+	}
+}
+
+func TestParse_UnknownKey(t *testing.T) {
+	_, err := Parse([]byte("jobs:\n  - name: report\n    schedule: daily\n"), FormatYAML)
+	assert.Error(t, err)
+
+	_, err = Parse([]byte("[[jobs]]\nname = \"report\"\nschedule = \"daily\"\n"), FormatTOML)
+	assert.EqualError(t, err, "unknown config key : jobs.schedule")
+}
+
+func TestParse_EmptyYAML(t *testing.T) {
+	definitions, err := Parse([]byte(""), FormatYAML)
+	assert.Nil(t, err)
+	assert.Empty(t, definitions)
+}
+
+func TestFormatOf(t *testing.T) {
+	format, err := FormatOf("jobs.yml")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatYAML, format)
+
+	format, err = FormatOf("jobs.TOML")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatTOML, format)
+
+	_, err = FormatOf("jobs.ini")
+	assert.EqualError(t, err, "unsupported config file : jobs.ini")
+}
+
+func TestScheduleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(`
+jobs:
+  - name: heartbeat
+    task: heartbeat
+    fixed_rate: 100ms
+  - name: report
+    task: send-report
+    cron: "0 0 9 * * *"
+`), 0o644))
+
+	scheduler := chrono.NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	executed := make(chan bool, 10)
+	assert.Nil(t, scheduler.RegisterTask("heartbeat", func(ctx context.Context) {
+		executed <- true
+	}))
+
+	err := ScheduleFile(scheduler, path)
+	assert.EqualError(t, err, "job report : no task registered with name send-report")
+
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("heartbeat has not been executed")
+	}
+
+	_, ok := scheduler.GetTask("heartbeat")
+	assert.True(t, ok)
+}
+
+func TestNewFileWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.toml")
+	assert.Nil(t, os.WriteFile(path, []byte("[[jobs]]\nname = \"heartbeat\"\ntask = \"heartbeat\"\nfixed_rate = \"1h\"\n"), 0o644))
+
+	scheduler := chrono.NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Nil(t, scheduler.RegisterTask("heartbeat", func(ctx context.Context) {}))
+
+	reconciler, err := chrono.NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	_, err = NewFileWatcher(reconciler, "jobs.ini")
+	assert.EqualError(t, err, "unsupported config file : jobs.ini")
+
+	results := make(chan chrono.ReconcileResult, 1)
+	watcher, err := NewFileWatcher(reconciler, path, chrono.WithReconcileCallback(func(result chrono.ReconcileResult, err error) {
+		assert.Nil(t, err)
+		results <- result
+	}))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Watch(ctx)
+
+	select {
+	case result := <-results:
+		assert.Equal(t, []string{"heartbeat"}, result.Added)
+	case <-time.After(time.Second):
+		t.Fatal("jobs have not been reconciled")
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	type config struct {
+		Timezone string                 `json:"timezone" yaml:"timezone" toml:"timezone"`
+		Jobs     []chrono.JobDefinition `json:"jobs" yaml:"jobs" toml:"jobs"`
+	}
+
+	testCases := []struct {
+		format Format
+		data   string
+	}{
+		{FormatJSON, `{"timezone": "UTC", "jobs": [{"name": "report", "task": "send-report"}]}`},
+		{FormatYAML, "timezone: UTC\njobs:\n  - name: report\n    task: send-report\n"},
+		{FormatTOML, "timezone = \"UTC\"\n[[jobs]]\nname = \"report\"\ntask = \"send-report\"\n"},
+	}
+
+	for _, testCase := range testCases {
+		var value config
+		assert.Nil(t, Unmarshal([]byte(testCase.data), testCase.format, &value), testCase.format.String())
+		assert.Equal(t, "UTC", value.Timezone)
+		assert.Equal(t, []chrono.JobDefinition{{Name: "report", Task: "send-report"}}, value.Jobs)
+	}
+
+	err := Unmarshal([]byte(`{"timezone": "UTC", "zone": "UTC"}`), FormatJSON, &config{})
+	assert.EqualError(t, err, `json: unknown field "zone"`)
+
+	err = Unmarshal([]byte(`{}`), Format(5), &config{})
+	assert.EqualError(t, err, "unknown config format : 5")
+}
@@ -0,0 +1,15 @@
+module codnect.io/chrono/chronoconfig
+
+go 1.21
+
+require (
+	codnect.io/chrono v0.0.0-20261019021254-290fdbb92b37
+	github.com/BurntSushi/toml v1.4.0
+	github.com/stretchr/testify v1.9.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
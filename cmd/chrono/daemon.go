@@ -0,0 +1,161 @@
+package main
+
+import (
+	"codnect.io/chrono"
+	"codnect.io/chrono/chronoconfig"
+	"codnect.io/chrono/crontab"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+)
+
+// daemonConfig is the config of the jobs run by the daemon, whose tasks are the commands defined under the tasks key.
+type daemonConfig struct {
+	Tasks map[string]commandConfig `json:"tasks" yaml:"tasks" toml:"tasks"`
+	Jobs  []chrono.JobDefinition   `json:"jobs" yaml:"jobs" toml:"jobs"`
+}
+
+type commandConfig struct {
+	Command string   `json:"command" yaml:"command" toml:"command"`
+	Env     []string `json:"env,omitempty" yaml:"env,omitempty" toml:"env,omitempty"`
+	Dir     string   `json:"dir,omitempty" yaml:"dir,omitempty" toml:"dir,omitempty"`
+}
+
+// runCommand runs the jobs in the config until the context is cancelled, and then shuts the scheduler down
+// after waiting for the running executions.
+func runCommand(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	configPath := flags.String("config", "", "config file defining the tasks and the jobs")
+	logLevel := flags.String("log-level", "info", "level of the logs: debug, info, warn or error")
+
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *configPath == "" || flags.NArg() != 0 {
+		fmt.Fprint(stderr, "usage: chrono run --config <file> [--log-level level]\n")
+		return exitUsage
+	}
+
+	var level slog.Level
+
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		fmt.Fprintf(stderr, "unknown log level : %s\n", *logLevel)
+		return exitUsage
+	}
+
+	config, err := loadDaemonConfig(*configPath)
+
+	if err != nil {
+		fmt.Fprintf(stderr, "config could not be loaded : %s\n", err)
+		return exitInvalid
+	}
+
+	logger := slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: level}))
+
+	scheduler := chrono.NewSimpleTaskScheduler(nil)
+	scheduler.SetLogger(logger)
+
+	if err = scheduleDaemonJobs(scheduler, config); err != nil {
+		fmt.Fprintf(stderr, "jobs could not be scheduled :\n%s\n", err)
+		<-scheduler.Shutdown()
+		return exitInvalid
+	}
+
+	logger.Info("scheduler started", slog.Int("jobs", len(config.Jobs)))
+
+	<-ctx.Done()
+
+	logger.Info("waiting for the running executions to complete")
+	<-scheduler.Shutdown()
+	logger.Info("scheduler stopped")
+
+	return exitOK
+}
+
+func loadDaemonConfig(path string) (daemonConfig, error) {
+	var config daemonConfig
+
+	format, err := chronoconfig.FormatOf(path)
+
+	if err != nil {
+		return config, err
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return config, err
+	}
+
+	if err = chronoconfig.Unmarshal(data, format, &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// scheduleDaemonJobs registers the commands as tasks and schedules the jobs. No job is scheduled if
+// any of them is invalid.
+func scheduleDaemonJobs(scheduler *chrono.SimpleTaskScheduler, config daemonConfig) error {
+	var errs []error
+
+	names := make([]string, 0, len(config.Tasks))
+
+	for name := range config.Tasks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		command := config.Tasks[name]
+
+		if command.Command == "" {
+			errs = append(errs, fmt.Errorf("task %s : command cannot be empty", name))
+			continue
+		}
+
+		task, err := chrono.NewCommandTask(crontab.DefaultShell, []string{"-c", command.Command},
+			chrono.WithCommandEnv(command.Env...),
+			chrono.WithCommandDir(command.Dir),
+		)
+
+		if err == nil {
+			err = scheduler.RegisterTask(name, task)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("task %s : %w", name, err))
+		}
+	}
+
+	for index, definition := range config.Jobs {
+		if _, err := definition.Job(); err != nil {
+			errs = append(errs, fmt.Errorf("job %s : %w", jobName(definition, index), err))
+		} else if _, ok := config.Tasks[definition.Task]; !ok {
+			errs = append(errs, fmt.Errorf("job %s : no task defined with name %s", jobName(definition, index), definition.Task))
+		}
+	}
+
+	if len(errs) != 0 {
+		return errors.Join(errs...)
+	}
+
+	return scheduler.ScheduleJobDefinitions(config.Jobs)
+}
+
+func jobName(definition chrono.JobDefinition, index int) string {
+	if definition.Name == "" {
+		return fmt.Sprintf("#%d", index+1)
+	}
+
+	return definition.Name
+}
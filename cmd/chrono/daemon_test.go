@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands are run with a POSIX shell")
+	}
+
+	output := filepath.Join(t.TempDir(), "output")
+	config := writeConfig(t, "jobs.yaml", `
+tasks:
+  heartbeat:
+    command: echo beat >> "$OUTPUT"
+    env: ["OUTPUT=`+output+`"]
+  slow:
+    command: sleep 0.5 && echo done >> "$OUTPUT"
+    env: ["OUTPUT=`+output+`"]
+jobs:
+  - name: heartbeat
+    task: heartbeat
+    fixed_rate: 100ms
+  - name: slow
+    task: slow
+    start_time: `+time.Now().Format(time.RFC3339Nano)+`
+`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Second, cancel)
+
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, []string{"run", "--config", config}, &stdout, &stderr)
+	assert.Equal(t, exitOK, code)
+
+	content, err := os.ReadFile(output)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, strings.Count(string(content), "beat"), 5)
+	assert.Contains(t, string(content), "done")
+
+	assert.Contains(t, stderr.String(), "msg=\"scheduler started\" jobs=2")
+	assert.Contains(t, stderr.String(), "msg=\"scheduler stopped\"")
+}
+
+func TestRunCommand_InvalidConfig(t *testing.T) {
+	testCases := []struct {
+		config string
+		err    string
+	}{
+		{"tasks:\n  backup:\n    cmd: backup.sh\n", "config could not be loaded : yaml: unmarshal errors:\n" +
+			"  line 3: field cmd not found in type main.commandConfig\n"},
+		{"tasks:\n  backup:\n    command: ''\njobs:\n  - name: backup\n    task: unknown\n    cron: '0 0 0 * * *'\n" +
+			"  - name: cleanup\n    task: backup\n    fixed_rate: 1x\n",
+			"jobs could not be scheduled :\ntask backup : command cannot be empty\n" +
+				"job backup : no task defined with name unknown\njob cleanup : invalid fixed rate : 1x\n"},
+	}
+
+	for _, testCase := range testCases {
+		code, _, stderr := runCLI("run", "--config", writeConfig(t, "jobs.yaml", testCase.config))
+		assert.Equal(t, exitInvalid, code)
+		assert.True(t, strings.HasPrefix(stderr, testCase.err), stderr)
+	}
+
+	code, _, stderr := runCLI("run", "--config", "jobs.ini")
+	assert.Equal(t, exitInvalid, code)
+	assert.Equal(t, "config could not be loaded : unsupported config file : jobs.ini\n", stderr)
+
+	code, _, stderr = runCLI("run")
+	assert.Equal(t, exitUsage, code)
+	assert.Equal(t, "usage: chrono run --config <file> [--log-level level]\n", stderr)
+
+	code, _, stderr = runCLI("run", "--config", "jobs.yaml", "--log-level", "verbose")
+	assert.Equal(t, exitUsage, code)
+	assert.Equal(t, "unknown log level : verbose\n", stderr)
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"codnect.io/chrono"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	monthNames = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September",
+		"October", "November", "December"}
+	dayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+)
+
+// cronUnit is a field of a cron expression as it is described.
+type cronUnit struct {
+	singular string
+	plural   string
+	// names are the names of the values starting from zero, which are used instead of the numbers if given.
+	names []string
+	// aliases are the abbreviations which can be used instead of the numbers in the expressions.
+	aliases []string
+}
+
+var (
+	secondUnit     = cronUnit{singular: "second", plural: "seconds"}
+	minuteUnit     = cronUnit{singular: "minute", plural: "minutes"}
+	hourUnit       = cronUnit{singular: "hour", plural: "hours"}
+	dayOfMonthUnit = cronUnit{singular: "day of the month", plural: "days of the month"}
+	monthUnit      = cronUnit{singular: "month", plural: "months", names: append([]string{""}, monthNames...),
+		aliases: []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	dayOfWeekUnit = cronUnit{singular: "day of the week", plural: "days of the week", names: dayNames,
+		aliases: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// explainCommand prints the description of the cron expression.
+func explainCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	expression, ok := expressionArg(args, stderr)
+
+	if !ok {
+		return exitUsage
+	}
+
+	if _, err := chrono.ParseCronExpression(expression); err != nil {
+		fmt.Fprint(stderr, describeError(expression, err))
+		return exitInvalid
+	}
+
+	fmt.Fprintln(stdout, explain(expression))
+	return exitOK
+}
+
+// explain describes a valid cron expression such as "0 30 9 * * MON-FRI" as "At 09:30:00, on Monday through Friday".
+func explain(expression string) string {
+	fields := strings.Fields(strings.ToUpper(expression))
+	clauses := []string{explainTime(fields[0], fields[1], fields[2])}
+
+	if fields[3] != "*" {
+		clauses = append(clauses, explainDate(fields[3], dayOfMonthUnit, "on"))
+	}
+
+	if fields[4] != "*" {
+		clauses = append(clauses, explainDate(fields[4], monthUnit, "in"))
+	}
+
+	if fields[5] != "*" {
+		clauses = append(clauses, explainDate(fields[5], dayOfWeekUnit, "on"))
+	}
+
+	return strings.Join(clauses, ", ")
+}
+
+func explainTime(second string, minute string, hour string) string {
+	if isNumber(second) && isNumber(minute) && isNumber(hour) {
+		return fmt.Sprintf("At %02d:%02d:%02d", number(hour), number(minute), number(second))
+	}
+
+	if second == "*" && minute == "*" && hour == "*" {
+		return "Every second"
+	}
+
+	var clauses []string
+
+	if isNumber(second) && isNumber(minute) {
+		if second == "0" && minute == "0" {
+			clauses = append(clauses, "At the start of the hour")
+		} else if second == "0" {
+			clauses = append(clauses, fmt.Sprintf("At %s %s past the hour", minute, plural(minute, minuteUnit)))
+		} else {
+			clauses = append(clauses, fmt.Sprintf("At %s %s and %s %s past the hour",
+				minute, plural(minute, minuteUnit), second, plural(second, secondUnit)))
+		}
+	} else {
+		clauses = append(clauses, explainField(second, secondUnit))
+
+		if minute != "*" || hour != "*" {
+			clauses = append(clauses, explainField(minute, minuteUnit))
+		}
+
+		clauses[0] = strings.ToUpper(clauses[0][:1]) + clauses[0][1:]
+	}
+
+	if hour != "*" {
+		clauses = append(clauses, explainField(hour, hourUnit))
+	}
+
+	return strings.Join(clauses, ", ")
+}
+
+func explainDate(value string, unit cronUnit, preposition string) string {
+	if strings.Contains(value, "/") && !strings.Contains(value, ",") {
+		return explainValues(value, unit)
+	}
+
+	if unit.names != nil {
+		return preposition + " " + explainValues(value, unit)
+	}
+
+	if isNumber(value) {
+		return fmt.Sprintf("%s day %s of the month", preposition, value)
+	}
+
+	return fmt.Sprintf("%s days %s of the month", preposition, explainValues(value, unit))
+}
+
+// explainField describes a field of the time such as "*/5" as "every 5 minutes".
+func explainField(value string, unit cronUnit) string {
+	switch {
+	case value == "*":
+		return "every " + unit.singular
+	case isNumber(value):
+		return "at " + unit.singular + " " + value
+	case strings.Contains(value, "/") && !strings.Contains(value, ","):
+		return explainValues(value, unit)
+	}
+
+	return unit.plural + " " + explainValues(value, unit)
+}
+
+// explainValues describes the values of a field, which are separated by commas and can be ranges with steps.
+func explainValues(value string, unit cronUnit) string {
+	parts := strings.Split(value, ",")
+	descriptions := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		rangeValue, step, hasStep := strings.Cut(part, "/")
+		from, to, isRange := strings.Cut(rangeValue, "-")
+
+		var description string
+
+		switch {
+		case hasStep && isRange:
+			description = fmt.Sprintf("every %s %s from %s through %s", step, plural(step, unit),
+				valueName(from, unit), valueName(to, unit))
+		case hasStep && rangeValue == "*":
+			description = fmt.Sprintf("every %s %s", step, plural(step, unit))
+		case hasStep:
+			description = fmt.Sprintf("every %s %s starting at %s", step, plural(step, unit), valueName(rangeValue, unit))
+		case isRange:
+			description = fmt.Sprintf("%s through %s", valueName(from, unit), valueName(to, unit))
+		case rangeValue == "*":
+			description = "every " + unit.singular
+		default:
+			description = valueName(rangeValue, unit)
+		}
+
+		descriptions = append(descriptions, description)
+	}
+
+	if len(descriptions) == 1 {
+		return descriptions[0]
+	}
+
+	return strings.Join(descriptions[:len(descriptions)-1], ", ") + " and " + descriptions[len(descriptions)-1]
+}
+
+func valueName(value string, unit cronUnit) string {
+	for index, alias := range unit.aliases {
+		if alias != "" && value == alias {
+			value = strconv.Itoa(index)
+			break
+		}
+	}
+
+	number, err := strconv.Atoi(value)
+
+	if err != nil || unit.names == nil || number < 0 || number >= len(unit.names) {
+		return value
+	}
+
+	return unit.names[number]
+}
+
+func plural(value string, unit cronUnit) string {
+	if value == "1" {
+		return unit.singular
+	}
+
+	return unit.plural
+}
+
+func number(value string) int {
+	number, _ := strconv.Atoi(value)
+	return number
+}
+
+func isNumber(value string) bool {
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	testCases := []struct {
+		expression  string
+		description string
+	}{
+		{"* * * * * *", "Every second"},
+		{"*/10 * * * * *", "Every 10 seconds"},
+		{"0 */5 * * * *", "At second 0, every 5 minutes"},
+		{"0 0 * * * *", "At the start of the hour"},
+		{"0 15 * * * *", "At 15 minutes past the hour"},
+		{"30 15 9-17 * * *", "At 15 minutes and 30 seconds past the hour, hours 9 through 17"},
+		{"0 30 9 * * MON-FRI", "At 09:30:00, on Monday through Friday"},
+		{"0 0 0 1 * *", "At 00:00:00, on day 1 of the month"},
+		{"0 0 12 1,15 * *", "At 12:00:00, on days 1 and 15 of the month"},
+		{"0 0 0 1 jan,jul *", "At 00:00:00, on day 1 of the month, in January and July"},
+		{"0 0 8 * */3 *", "At 08:00:00, every 3 months"},
+		{"0 0 10 * * 0,6", "At 10:00:00, on Sunday and Saturday"},
+		{"0 0 */2 * * *", "At the start of the hour, every 2 hours"},
+		{"0 0-30/10 8 * * *", "At second 0, every 10 minutes from 0 through 30, at hour 8"},
+		{"5/15 * * * * *", "Every 15 seconds starting at 5"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.description, explain(testCase.expression), testCase.expression)
+	}
+}
+
+func TestExplainCommand(t *testing.T) {
+	code, stdout, _ := runCLI("explain", "0 30 9 * * MON-FRI")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "At 09:30:00, on Monday through Friday\n", stdout)
+
+	code, _, stderr := runCLI("explain", "0 61 * * * *")
+	assert.Equal(t, exitInvalid, code)
+	assert.Contains(t, stderr, "the value in field MINUTE must be between 0 and 59")
+}
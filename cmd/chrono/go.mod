@@ -0,0 +1,16 @@
+module codnect.io/chrono/cmd/chrono
+
+go 1.21
+
+require (
+	codnect.io/chrono v0.0.0-20261019021254-290fdbb92b37
+	codnect.io/chrono/chronoconfig v0.0.0-20261019021348-8c564229a8c4
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.4.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,70 @@
+// Command chrono validates, inspects and explains cron expressions, and runs the jobs defined in a config file.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+const usage = `Usage: chrono <command> [flags] [arguments]
+
+Commands:
+  validate <expression>                     validate a cron expression
+  next [-n count] [--tz zone] <expression>  print the next execution times of a cron expression
+  explain <expression>                      describe a cron expression
+  run --config <file>                       run the jobs defined in a config file until terminated
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "validate":
+		return validateCommand(args[1:], stdout, stderr)
+	case "next":
+		return nextCommand(args[1:], stdout, stderr)
+	case "explain":
+		return explainCommand(args[1:], stdout, stderr)
+	case "run":
+		return runCommand(ctx, args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "unknown command : %s\n\n%s", args[0], usage)
+	return exitUsage
+}
+
+// expressionArg returns the cron expression given as the only argument. The expression can also be given
+// without quotes, in which case its fields are passed as separate arguments.
+func expressionArg(args []string, stderr io.Writer) (string, bool) {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, "cron expression is missing\n")
+		return "", false
+	}
+
+	return strings.Join(args, " "), true
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	code, _, stderr := runCLI()
+	assert.Equal(t, exitUsage, code)
+	assert.Equal(t, usage, stderr)
+
+	code, stdout, _ := runCLI("help")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, usage, stdout)
+
+	code, _, stderr = runCLI("schedule")
+	assert.Equal(t, exitUsage, code)
+	assert.Equal(t, "unknown command : schedule\n\n"+usage, stderr)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"codnect.io/chrono"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+)
+
+const maxNextCount = 1000
+
+// nextCommand prints the next execution times of the cron expression computed by a cron trigger.
+func nextCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("next", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	count := flags.Int("n", 10, "number of the execution times")
+	zone := flags.String("tz", "Local", "time zone the expression is evaluated in")
+	from := flags.String("from", "", "time in RFC 3339 format the execution times are computed after (default now)")
+
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *count < 1 || *count > maxNextCount {
+		fmt.Fprintf(stderr, "n must be between 1 and %d\n", maxNextCount)
+		return exitUsage
+	}
+
+	expression, ok := expressionArg(flags.Args(), stderr)
+
+	if !ok {
+		return exitUsage
+	}
+
+	location, err := time.LoadLocation(*zone)
+
+	if err != nil {
+		fmt.Fprintf(stderr, "location not loaded : %s\n", *zone)
+		return exitUsage
+	}
+
+	start := time.Now()
+
+	if *from != "" {
+		if start, err = time.Parse(time.RFC3339, *from); err != nil {
+			fmt.Fprintf(stderr, "invalid time : %s\n", *from)
+			return exitUsage
+		}
+	}
+
+	trigger, err := chrono.CreateCronTrigger(expression, location)
+
+	if err != nil {
+		fmt.Fprint(stderr, describeError(expression, err))
+		return exitInvalid
+	}
+
+	for _, executionTime := range nextExecutionTimes(trigger, start.In(location), *count) {
+		fmt.Fprintln(stdout, executionTime.Format(time.RFC3339))
+	}
+
+	return exitOK
+}
+
+// nextExecutionTimes returns the execution times of the trigger after the start time.
+func nextExecutionTimes(trigger *chrono.CronTrigger, start time.Time, count int) []time.Time {
+	executionTimes := make([]time.Time, 0, count)
+	next := start
+
+	for len(executionTimes) < count {
+		if next = trigger.NextExecutionTimeAfter(next); next.IsZero() {
+			break
+		}
+
+		executionTimes = append(executionTimes, next)
+	}
+
+	return executionTimes
+}
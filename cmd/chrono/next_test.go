@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNextCommand(t *testing.T) {
+	code, stdout, stderr := runCLI("next", "-n", "3", "--tz", "Europe/Istanbul", "--from", "2024-03-01T00:00:00Z",
+		"0 30 9 * * MON-FRI")
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, "2024-03-01T09:30:00+03:00\n"+
+		"2024-03-04T09:30:00+03:00\n"+
+		"2024-03-05T09:30:00+03:00\n", stdout)
+}
+
+func TestNextCommand_DefaultCount(t *testing.T) {
+	code, stdout, _ := runCLI("next", "--tz", "UTC", "0 0 * * * *")
+	assert.Equal(t, exitOK, code)
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 10)
+}
+
+func TestNextCommand_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{[]string{"next", "-n", "0", "* * * * * *"}, exitUsage, "n must be between 1 and 1000\n"},
+		{[]string{"next", "--tz", "Nowhere/City", "* * * * * *"}, exitUsage, "location not loaded : Nowhere/City\n"},
+		{[]string{"next", "--from", "yesterday", "* * * * * *"}, exitUsage, "invalid time : yesterday\n"},
+		{[]string{"next", "-n", "3"}, exitUsage, "cron expression is missing\n"},
+		{[]string{"next", "* * * * 13 *"}, exitInvalid, "* * * * 13 *\n" +
+			"        ^^\n" +
+			"field 5 (MONTH) at column 9 : the value in field MONTH must be between 1 and 12\n"},
+	}
+
+	for _, testCase := range testCases {
+		code, _, stderr := runCLI(testCase.args...)
+		assert.Equal(t, testCase.code, code, testCase.args)
+		assert.Equal(t, testCase.stderr, stderr, testCase.args)
+	}
+}
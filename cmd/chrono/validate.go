@@ -0,0 +1,45 @@
+package main
+
+import (
+	"codnect.io/chrono"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// validateCommand validates the cron expression, and points to the invalid field of it.
+func validateCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	expression, ok := expressionArg(args, stderr)
+
+	if !ok {
+		return exitUsage
+	}
+
+	if _, err := chrono.ParseCronExpression(expression); err != nil {
+		fmt.Fprint(stderr, describeError(expression, err))
+		return exitInvalid
+	}
+
+	fmt.Fprintln(stdout, "valid")
+	return exitOK
+}
+
+// describeError returns the error with the expression, marking the invalid field if it is known.
+func describeError(expression string, err error) string {
+	var fieldErr *chrono.CronFieldError
+
+	if !errors.As(err, &fieldErr) {
+		return fmt.Sprintf("invalid cron expression : %s\n", err)
+	}
+
+	return fmt.Sprintf("%s\n%s%s\nfield %d (%s) at column %d : %s\n",
+		expression,
+		strings.Repeat(" ", fieldErr.Offset),
+		strings.Repeat("^", len(fieldErr.Value)),
+		fieldErr.Index+1,
+		fieldErr.Field,
+		fieldErr.Offset+1,
+		err,
+	)
+}
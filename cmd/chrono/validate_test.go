@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateCommand(t *testing.T) {
+	code, stdout, stderr := runCLI("validate", "0 */5 9-17 * * MON-FRI")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "valid\n", stdout)
+	assert.Empty(t, stderr)
+
+	code, stdout, _ = runCLI("validate", "0", "0", "*", "*", "*", "*")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "valid\n", stdout)
+}
+
+func TestValidateCommand_InvalidExpression(t *testing.T) {
+	code, stdout, stderr := runCLI("validate", "0 0 25 * * *")
+	assert.Equal(t, exitInvalid, code)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "0 0 25 * * *\n"+
+		"    ^^\n"+
+		"field 3 (HOUR) at column 5 : the value in field HOUR must be between 0 and 23\n", stderr)
+
+	code, _, stderr = runCLI("validate", "0 0 * * *")
+	assert.Equal(t, exitInvalid, code)
+	assert.Equal(t, "invalid cron expression : cron expression must consist of 6 fields : found 5 in \"0 0 * * *\"\n", stderr)
+
+	code, _, stderr = runCLI("validate")
+	assert.Equal(t, exitUsage, code)
+	assert.Equal(t, "cron expression is missing\n", stderr)
+}
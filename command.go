@@ -0,0 +1,243 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxOutputSize is how many bytes of the standard output and error of a command are kept
+// unless WithMaxOutputSize is used.
+const DefaultMaxOutputSize = 64 * 1024
+
+// commandWaitDelay is how long the output of a killed command is waited for, since it might be kept
+// open by the processes which are not in its process group.
+const commandWaitDelay = time.Second
+
+// CommandResult is the result of an execution of a command task, which is set as the result of the execution.
+type CommandResult struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	// Truncated reports whether any of the outputs is longer than the max output size.
+	Truncated bool
+}
+
+// CommandExitError is the error an execution of a command task fails with when the command exits with
+// a non-zero code.
+type CommandExitError struct {
+	ExitCode int
+}
+
+func (err *CommandExitError) Error() string {
+	return fmt.Sprintf("command exited with code %d", err.ExitCode)
+}
+
+type CommandOption func(command *commandTask) error
+
+// WithCommandDir sets the working directory of the command.
+func WithCommandDir(dir string) CommandOption {
+	return func(command *commandTask) error {
+		command.dir = dir
+		return nil
+	}
+}
+
+// WithCommandEnv adds the variables in the form of NAME=value to the environment of the command,
+// which is inherited from the process. The variables override the inherited ones with the same names.
+func WithCommandEnv(env ...string) CommandOption {
+	return func(command *commandTask) error {
+		for _, variable := range env {
+			if !strings.Contains(variable, "=") {
+				return fmt.Errorf("invalid environment variable : %s", variable)
+			}
+		}
+
+		command.env = append(command.env, env...)
+		return nil
+	}
+}
+
+// WithCommandInput sets the text written to the standard input of the command.
+func WithCommandInput(input string) CommandOption {
+	return func(command *commandTask) error {
+		command.input = input
+		return nil
+	}
+}
+
+// WithMaxOutputSize sets how many bytes of each of the standard output and error of the command are kept.
+// The rest of the output is discarded.
+func WithMaxOutputSize(size int) CommandOption {
+	return func(command *commandTask) error {
+		if size < 0 {
+			return errors.New("max output size cannot be negative")
+		}
+
+		command.maxOutputSize = size
+		return nil
+	}
+}
+
+// WithCommandGracePeriod makes the command to be terminated gracefully when the context of the execution
+// is cancelled. Its process group is sent SIGTERM first, and it is killed if it is still running after the
+// grace period. The command is killed at once by default.
+func WithCommandGracePeriod(gracePeriod time.Duration) CommandOption {
+	return func(command *commandTask) error {
+		if gracePeriod < 0 {
+			return errors.New("grace period cannot be negative")
+		}
+
+		command.gracePeriod = gracePeriod
+		return nil
+	}
+}
+
+type commandTask struct {
+	name          string
+	args          []string
+	dir           string
+	env           []string
+	input         string
+	maxOutputSize int
+	gracePeriod   time.Duration
+}
+
+// NewCommandTask returns a task running the named program with the given arguments. The command runs in its
+// own process group, so that the processes started by it are also terminated once the context of the execution
+// is cancelled. The exit code and the outputs of the command are set as the CommandResult of the execution,
+// and the execution fails with a CommandExitError if the command exits with a non-zero code.
+func NewCommandTask(name string, args []string, options ...CommandOption) (Task, error) {
+	if name == "" {
+		return nil, errors.New("command name cannot be empty")
+	}
+
+	command := &commandTask{
+		name:          name,
+		args:          append([]string{}, args...),
+		maxOutputSize: DefaultMaxOutputSize,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err := option(command); err != nil {
+			return nil, err
+		}
+	}
+
+	return command.run, nil
+}
+
+func (command *commandTask) run(ctx context.Context) {
+	stdout := &limitedBuffer{limit: command.maxOutputSize}
+	stderr := &limitedBuffer{limit: command.maxOutputSize}
+
+	cmd := exec.CommandContext(ctx, command.name, command.args...)
+	cmd.Dir = command.dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = command.gracePeriod + commandWaitDelay
+
+	if len(command.env) != 0 {
+		cmd.Env = append(os.Environ(), command.env...)
+	}
+
+	if command.input != "" {
+		cmd.Stdin = strings.NewReader(command.input)
+	}
+
+	setProcessGroup(cmd)
+
+	var killTimer *time.Timer
+
+	cmd.Cancel = func() error {
+		if command.gracePeriod == 0 {
+			return killProcessGroup(cmd)
+		}
+
+		killTimer = time.AfterFunc(command.gracePeriod, func() {
+			killProcessGroup(cmd)
+		})
+
+		return terminateProcessGroup(cmd)
+	}
+
+	err := cmd.Run()
+
+	if killTimer != nil {
+		killTimer.Stop()
+	}
+
+	result := CommandResult{
+		ExitCode:  -1,
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	execution, ok := ExecutionFromContext(ctx)
+
+	if !ok {
+		return
+	}
+
+	execution.SetResult(result)
+
+	if err == nil {
+		return
+	}
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case ctx.Err() != nil:
+		execution.Fail(fmt.Errorf("command is terminated : %w", context.Cause(ctx)))
+	case errors.As(err, &exitErr) && result.ExitCode > 0:
+		execution.Fail(&CommandExitError{ExitCode: result.ExitCode})
+	default:
+		execution.Fail(err)
+	}
+}
+
+// limitedBuffer keeps the first bytes written to it up to its limit, and discards the rest.
+type limitedBuffer struct {
+	buffer    strings.Builder
+	limit     int
+	truncated bool
+	mu        sync.Mutex
+}
+
+func (buffer *limitedBuffer) Write(p []byte) (int, error) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	remaining := buffer.limit - buffer.buffer.Len()
+
+	if len(p) > remaining {
+		buffer.truncated = true
+		buffer.buffer.Write(p[:remaining])
+	} else {
+		buffer.buffer.Write(p)
+	}
+
+	// the whole output is reported as written, so that the command is not failed because of the limit
+	return len(p), nil
+}
+
+func (buffer *limitedBuffer) String() string {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	return buffer.buffer.String()
+}
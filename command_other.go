@@ -0,0 +1,20 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package chrono
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on this platform, so only the process of the command is terminated.
+func setProcessGroup(cmd *exec.Cmd) {
+}
+
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Signal(os.Interrupt)
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
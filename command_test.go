@@ -0,0 +1,145 @@
+package chrono
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runCommandTask(t *testing.T, name string, args []string, commandOptions []CommandOption, options ...Option) ExecutionRecord {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands are run with a POSIX shell")
+	}
+
+	command, err := NewCommandTask(name, args, commandOptions...)
+	assert.Nil(t, err)
+
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	task, err := scheduler.Schedule(command, options...)
+	assert.Nil(t, err)
+
+	for i := 0; i < 50; i++ {
+		<-time.After(100 * time.Millisecond)
+
+		if history := task.(HistoryProvider).History(); len(history) != 0 {
+			return history[0]
+		}
+	}
+
+	t.Fatal("command has not been run")
+	return ExecutionRecord{}
+}
+
+func TestNewCommandTask(t *testing.T) {
+	dir := t.TempDir()
+	dir, _ = filepath.EvalSymlinks(dir)
+
+	record := runCommandTask(t, "/bin/sh", []string{"-c", `echo "$GREETING, $(cat) from $(pwd)"; echo warning >&2`},
+		[]CommandOption{
+			WithCommandDir(dir),
+			WithCommandEnv("GREETING=hello"),
+			WithCommandInput("world"),
+		})
+
+	assert.Equal(t, ExecutionSucceeded, record.Outcome)
+	assert.Nil(t, record.Err)
+	assert.Equal(t, CommandResult{
+		ExitCode: 0,
+		Stdout:   "hello, world from " + dir + "\n",
+		Stderr:   "warning\n",
+	}, record.Result)
+}
+
+func TestNewCommandTask_InvalidOptions(t *testing.T) {
+	_, err := NewCommandTask("", nil)
+	assert.EqualError(t, err, "command name cannot be empty")
+
+	_, err = NewCommandTask("true", nil, WithCommandEnv("GREETING"))
+	assert.EqualError(t, err, "invalid environment variable : GREETING")
+
+	_, err = NewCommandTask("true", nil, WithMaxOutputSize(-1))
+	assert.EqualError(t, err, "max output size cannot be negative")
+
+	_, err = NewCommandTask("true", nil, WithCommandGracePeriod(-time.Second))
+	assert.EqualError(t, err, "grace period cannot be negative")
+
+	_, err = NewCommandTask("true", nil, nil)
+	assert.Nil(t, err)
+}
+
+func TestNewCommandTask_NonZeroExitCode(t *testing.T) {
+	record := runCommandTask(t, "/bin/sh", []string{"-c", "echo failed >&2; exit 3"}, nil)
+
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.Equal(t, &CommandExitError{ExitCode: 3}, record.Err)
+	assert.EqualError(t, record.Err, "command exited with code 3")
+	assert.Equal(t, CommandResult{ExitCode: 3, Stderr: "failed\n"}, record.Result)
+}
+
+func TestNewCommandTask_CommandNotFound(t *testing.T) {
+	record := runCommandTask(t, filepath.Join(t.TempDir(), "missing"), nil, nil)
+
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.ErrorIs(t, record.Err, os.ErrNotExist)
+	assert.Equal(t, CommandResult{ExitCode: -1}, record.Result)
+}
+
+func TestNewCommandTask_MaxOutputSize(t *testing.T) {
+	record := runCommandTask(t, "/bin/sh", []string{"-c", "echo 0123456789; echo abc >&2"},
+		[]CommandOption{WithMaxOutputSize(4)})
+
+	assert.Equal(t, ExecutionSucceeded, record.Outcome)
+	assert.Equal(t, CommandResult{ExitCode: 0, Stdout: "0123", Stderr: "abc\n", Truncated: true}, record.Result)
+}
+
+func TestNewCommandTask_Timeout(t *testing.T) {
+	start := time.Now()
+	record := runCommandTask(t, "/bin/sh", []string{"-c", "echo started; sleep 5 & sleep 5"}, nil,
+		WithTimeout(200*time.Millisecond))
+
+	assert.Less(t, time.Since(start), 3*time.Second)
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.ErrorIs(t, record.Err, ErrExecutionTimeout)
+	assert.EqualError(t, record.Err, "command is terminated : "+ErrExecutionTimeout.Error())
+	assert.Equal(t, "started\n", record.Result.(CommandResult).Stdout)
+}
+
+func TestNewCommandTask_GracePeriod(t *testing.T) {
+	record := runCommandTask(t, "/bin/sh", []string{"-c", "trap 'echo terminated; exit 0' TERM; sleep 5 & wait"},
+		[]CommandOption{WithCommandGracePeriod(time.Second)}, WithTimeout(200*time.Millisecond))
+
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.ErrorIs(t, record.Err, ErrExecutionTimeout)
+	assert.Equal(t, "terminated\n", record.Result.(CommandResult).Stdout)
+}
+
+func TestNewCommandTask_GracePeriodExceeded(t *testing.T) {
+	start := time.Now()
+	record := runCommandTask(t, "/bin/sh", []string{"-c", "trap '' TERM; sleep 5"},
+		[]CommandOption{WithCommandGracePeriod(300 * time.Millisecond)}, WithTimeout(200*time.Millisecond))
+
+	assert.Less(t, time.Since(start), 3*time.Second)
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.ErrorIs(t, record.Err, ErrExecutionTimeout)
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buffer := &limitedBuffer{limit: 5}
+
+	n, err := buffer.Write([]byte("abc"))
+	assert.Equal(t, 3, n)
+	assert.Nil(t, err)
+	assert.False(t, buffer.truncated)
+
+	n, err = buffer.Write([]byte(strings.Repeat("d", 10)))
+	assert.Equal(t, 10, n)
+	assert.Nil(t, err)
+	assert.True(t, buffer.truncated)
+	assert.Equal(t, "abcdd", buffer.String())
+}
@@ -0,0 +1,179 @@
+package chrono
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// JobDefinition is the declarative definition of a job, which can be decoded from JSON, YAML or TOML.
+// The durations are given in the format accepted by time.ParseDuration such as "1m30s", and the task
+// is referred by the name it is registered with. A job is enabled unless Enabled is false.
+type JobDefinition struct {
+	Name              string           `json:"name" yaml:"name" toml:"name"`
+	Task              string           `json:"task" yaml:"task" toml:"task"`
+	Cron              string           `json:"cron,omitempty" yaml:"cron,omitempty" toml:"cron,omitempty"`
+	FixedRate         string           `json:"fixed_rate,omitempty" yaml:"fixed_rate,omitempty" toml:"fixed_rate,omitempty"`
+	FixedDelay        string           `json:"fixed_delay,omitempty" yaml:"fixed_delay,omitempty" toml:"fixed_delay,omitempty"`
+	Location          string           `json:"location,omitempty" yaml:"location,omitempty" toml:"location,omitempty"`
+	StartTime         time.Time        `json:"start_time,omitempty" yaml:"start_time,omitempty" toml:"start_time,omitempty"`
+	Timeout           string           `json:"timeout,omitempty" yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+	Retry             *RetryDefinition `json:"retry,omitempty" yaml:"retry,omitempty" toml:"retry,omitempty"`
+	ConcurrencyPolicy string           `json:"concurrency_policy,omitempty" yaml:"concurrency_policy,omitempty" toml:"concurrency_policy,omitempty"`
+	MisfirePolicy     string           `json:"misfire_policy,omitempty" yaml:"misfire_policy,omitempty" toml:"misfire_policy,omitempty"`
+	Enabled           *bool            `json:"enabled,omitempty" yaml:"enabled,omitempty" toml:"enabled,omitempty"`
+}
+
+type RetryDefinition struct {
+	MaxAttempts int    `json:"max_attempts" yaml:"max_attempts" toml:"max_attempts"`
+	Delay       string `json:"delay,omitempty" yaml:"delay,omitempty" toml:"delay,omitempty"`
+}
+
+// JobDefinitions is the root of a config file which defines the jobs under the jobs key.
+type JobDefinitions struct {
+	Jobs []JobDefinition `json:"jobs" yaml:"jobs" toml:"jobs"`
+}
+
+// ParseJobDefinitions parses the job definitions in JSON.
+func ParseJobDefinitions(data []byte) ([]JobDefinition, error) {
+	var definitions JobDefinitions
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&definitions); err != nil {
+		return nil, err
+	}
+
+	return definitions.Jobs, nil
+}
+
+func (definition JobDefinition) IsEnabled() bool {
+	return definition.Enabled == nil || *definition.Enabled
+}
+
+// Job converts the definition into a job after validating it.
+func (definition JobDefinition) Job() (Job, error) {
+	job := Job{
+		Name:      definition.Name,
+		TaskName:  definition.Task,
+		Cron:      definition.Cron,
+		Location:  definition.Location,
+		StartTime: definition.StartTime,
+	}
+
+	var err error
+
+	if job.FixedRate, err = parseDefinitionDuration("fixed rate", definition.FixedRate); err != nil {
+		return Job{}, err
+	}
+
+	if job.FixedDelay, err = parseDefinitionDuration("fixed delay", definition.FixedDelay); err != nil {
+		return Job{}, err
+	}
+
+	if job.Timeout, err = parseDefinitionDuration("timeout", definition.Timeout); err != nil {
+		return Job{}, err
+	}
+
+	if definition.Retry != nil {
+		job.RetryAttempts = definition.Retry.MaxAttempts
+
+		if job.RetryDelay, err = parseDefinitionDuration("retry delay", definition.Retry.Delay); err != nil {
+			return Job{}, err
+		}
+	}
+
+	switch definition.ConcurrencyPolicy {
+	case "", "allow":
+		job.ConcurrencyPolicy = ConcurrencyPolicyAllow
+	case "forbid":
+		job.ConcurrencyPolicy = ConcurrencyPolicyForbid
+	default:
+		return Job{}, fmt.Errorf("unknown concurrency policy : %s", definition.ConcurrencyPolicy)
+	}
+
+	switch definition.MisfirePolicy {
+	case "", "run_once":
+		job.MisfirePolicy = MisfirePolicyRunOnce
+	case "run_all":
+		job.MisfirePolicy = MisfirePolicyRunAll
+	case "skip":
+		job.MisfirePolicy = MisfirePolicySkip
+	default:
+		return Job{}, fmt.Errorf("unknown misfire policy : %s", definition.MisfirePolicy)
+	}
+
+	if err = job.validate(); err != nil {
+		return Job{}, err
+	}
+
+	if job.Cron != "" {
+		if _, err = ParseCronExpression(job.Cron); err != nil {
+			return Job{}, err
+		}
+	}
+
+	if job.Location != "" {
+		if _, err = time.LoadLocation(job.Location); err != nil {
+			return Job{}, fmt.Errorf("location not loaded : %s", job.Location)
+		}
+	}
+
+	if definition.Retry != nil && job.RetryAttempts < 1 {
+		return Job{}, errors.New("max attempts must be at least one")
+	}
+
+	return job, nil
+}
+
+func parseDefinitionDuration(field string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s : %s", field, value)
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("%s must be greater than zero", field)
+	}
+
+	return duration, nil
+}
+
+// ScheduleJobDefinitions schedules the enabled jobs in the given definitions with the tasks registered
+// by RegisterTask. All the jobs are tried to be scheduled, and the errors of the jobs which cannot be
+// scheduled are returned altogether. Unlike ScheduleJob, the jobs are not saved in the job store.
+func (scheduler *SimpleTaskScheduler) ScheduleJobDefinitions(definitions []JobDefinition) error {
+	var errs []error
+
+	for index, definition := range definitions {
+		if !definition.IsEnabled() {
+			continue
+		}
+
+		job, err := definition.Job()
+
+		if err == nil {
+			_, err = scheduler.scheduleJob(job, JobState{}, false)
+		}
+
+		if err != nil {
+			name := definition.Name
+
+			if name == "" {
+				name = fmt.Sprintf("#%d", index+1)
+			}
+
+			errs = append(errs, fmt.Errorf("job %s : %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
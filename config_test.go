@@ -0,0 +1,132 @@
+package chrono
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseJobDefinitions(t *testing.T) {
+	definitions, err := ParseJobDefinitions([]byte(`{
+		"jobs": [
+			{
+				"name": "report",
+				"task": "send-report",
+				"cron": "0 0 9 * * *",
+				"location": "UTC",
+				"timeout": "5m",
+				"retry": {"max_attempts": 3, "delay": "30s"},
+				"concurrency_policy": "forbid",
+				"misfire_policy": "run_all"
+			},
+			{
+				"name": "cleanup",
+				"task": "cleanup",
+				"fixed_delay": "1h",
+				"start_time": "2024-01-01T00:00:00Z",
+				"enabled": false
+			}
+		]
+	}`))
+	assert.Nil(t, err)
+	assert.Len(t, definitions, 2)
+
+	assert.True(t, definitions[0].IsEnabled())
+	job, err := definitions[0].Job()
+	assert.Nil(t, err)
+	assert.Equal(t, Job{
+		Name:              "report",
+		TaskName:          "send-report",
+		Cron:              "0 0 9 * * *",
+		Location:          "UTC",
+		MisfirePolicy:     MisfirePolicyRunAll,
+		ConcurrencyPolicy: ConcurrencyPolicyForbid,
+		Timeout:           5 * time.Minute,
+		RetryAttempts:     3,
+		RetryDelay:        30 * time.Second,
+	}, job)
+
+	assert.False(t, definitions[1].IsEnabled())
+	job, err = definitions[1].Job()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, job.FixedDelay)
+	assert.True(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Equal(job.StartTime))
+}
+
+func TestParseJobDefinitions_UnknownField(t *testing.T) {
+	_, err := ParseJobDefinitions([]byte(`{"jobs": [{"name": "report", "schedule": "daily"}]}`))
+	assert.EqualError(t, err, `json: unknown field "schedule"`)
+}
+
+func TestJobDefinition_Job(t *testing.T) {
+	testCases := []struct {
+		definition JobDefinition
+		err        string
+	}{
+		{JobDefinition{Name: "job", Task: "task", FixedRate: "1x"}, "invalid fixed rate : 1x"},
+		{JobDefinition{Name: "job", Task: "task", FixedDelay: "-1s"}, "fixed delay must be greater than zero"},
+		{JobDefinition{Name: "job", Task: "task", Timeout: "0s"}, "timeout must be greater than zero"},
+		{JobDefinition{Name: "job", Task: "task", Retry: &RetryDefinition{Delay: "1s"}}, "max attempts must be at least one"},
+		{JobDefinition{Name: "job", Task: "task", ConcurrencyPolicy: "never"}, "unknown concurrency policy : never"},
+		{JobDefinition{Name: "job", Task: "task", MisfirePolicy: "later"}, "unknown misfire policy : later"},
+		{JobDefinition{Name: "job", Task: "task", Cron: "* * *"}, "cron expression must consist of 6 fields : found 3 in \"* * *\""},
+		{JobDefinition{Name: "job", Task: "task", Location: "Nowhere/City"}, "location not loaded : Nowhere/City"},
+		{JobDefinition{Name: "job", Task: "task", Cron: "* * * * * *", FixedRate: "1s"},
+			"only one of cron, fixed delay and fixed rate can be given for a job"},
+		{JobDefinition{Task: "task"}, "job name cannot be empty"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := testCase.definition.Job()
+		assert.EqualError(t, err, testCase.err)
+	}
+}
+
+func TestSimpleTaskScheduler_ScheduleJobDefinitions(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	store := newMemoryJobStore()
+	scheduler.SetJobStore(store)
+
+	executed := make(chan bool, 1)
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {
+		executed <- true
+	}))
+
+	disabled := false
+
+	err := scheduler.ScheduleJobDefinitions([]JobDefinition{
+		{Name: "cron-job", Task: "task", Cron: "0 0 0 1 1 *"},
+		{Name: "rate-job", Task: "task", FixedRate: "1h"},
+		{Name: "disabled-job", Task: "task", FixedRate: "1h", Enabled: &disabled},
+		{Name: "unknown-job", Task: "unknown", FixedRate: "1h"},
+		{Task: "task", FixedRate: "1h"},
+		{Name: "invalid-job", Task: "task", FixedRate: "1 hour"},
+	})
+
+	assert.EqualError(t, err, "job unknown-job : no task registered with name unknown\n"+
+		"job #5 : job name cannot be empty\n"+
+		"job invalid-job : invalid fixed rate : 1 hour")
+
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("rate-job has not been executed")
+	}
+
+	cronTask, ok := scheduler.GetTask("cron-job")
+	assert.True(t, ok)
+	assert.IsType(t, &TriggerTask{}, cronTask)
+
+	_, ok = scheduler.GetTask("rate-job")
+	assert.True(t, ok)
+
+	_, ok = scheduler.GetTask("disabled-job")
+	assert.False(t, ok)
+
+	storedJobs, err := store.LoadJobs()
+	assert.Nil(t, err)
+	assert.Empty(t, storedJobs)
+}
@@ -151,6 +151,26 @@ func (expression *CronExpression) nextField(field *cronFieldBits, t time.Time) t
 	}
 }
 
+// CronFieldError is the error returned by ParseCronExpression for an invalid field of a cron expression.
+type CronFieldError struct {
+	// Field is the name of the field such as MINUTE or DAY_OF_WEEK.
+	Field string
+	// Index is the index of the field in the expression, starting from zero for the seconds.
+	Index int
+	// Offset is the byte offset of the field in the expression.
+	Offset int
+	Value  string
+	Err    error
+}
+
+func (err *CronFieldError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *CronFieldError) Unwrap() error {
+	return err.Err
+}
+
 func ParseCronExpression(expression string) (*CronExpression, error) {
 	if len(expression) == 0 {
 		return nil, errors.New("cron expression must not be empty")
@@ -164,13 +184,24 @@ func ParseCronExpression(expression string) (*CronExpression, error) {
 
 	cronExpression := newCronExpression()
 
+	offset := 0
+
 	for index, cronFieldType := range cronFieldTypes {
+		offset += strings.Index(expression[offset:], fields[index])
 		value, err := parseField(fields[index], cronFieldType)
 
 		if err != nil {
-			return nil, err
+			return nil, &CronFieldError{
+				Field:  string(cronFieldType.Field),
+				Index:  index,
+				Offset: offset,
+				Value:  fields[index],
+				Err:    err,
+			}
 		}
 
+		offset += len(fields[index])
+
 		if cronFieldType.Field == cronFieldDayOfWeek && value.Bits&1<<0 != 0 {
 			value.Bits |= 1 << 7
 			temp := ^(1 << 0)
@@ -1,6 +1,7 @@
 package chrono
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -658,3 +659,15 @@ func TestParseField_WhenValueIsEmpty(t *testing.T) {
 	assert.NotNil(t, err, "an error must have been occurred")
 	assert.Equal(t, "value must not be empty", err.Error())
 }
+
+func TestParseCronExpression_CronFieldError(t *testing.T) {
+	_, err := ParseCronExpression("0  */5 *  * 13 MON")
+
+	var fieldErr *CronFieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "MONTH", fieldErr.Field)
+	assert.Equal(t, 4, fieldErr.Index)
+	assert.Equal(t, 12, fieldErr.Offset)
+	assert.Equal(t, "13", fieldErr.Value)
+	assert.Equal(t, "the value in field MONTH must be between 1 and 12", err.Error())
+}
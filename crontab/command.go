@@ -0,0 +1,37 @@
+package crontab
+
+import (
+	"codnect.io/chrono"
+	"context"
+	"strings"
+)
+
+// Task returns the task running the command of the entry with the shell set by SHELL, which is /bin/sh by
+// default. The command inherits the environment of the process with the variables of the entry, and it is run
+// as a command task, so its process group is killed once the context of the execution is cancelled.
+func (entry Entry) Task() chrono.Task {
+	task, err := chrono.NewCommandTask(entry.shell(), []string{"-c", entry.Command},
+		chrono.WithCommandEnv(entry.Env...),
+		chrono.WithCommandInput(entry.Input),
+	)
+
+	if err != nil {
+		return func(ctx context.Context) {
+			if execution, ok := chrono.ExecutionFromContext(ctx); ok {
+				execution.Fail(err)
+			}
+		}
+	}
+
+	return task
+}
+
+func (entry Entry) shell() string {
+	for i := len(entry.Env) - 1; i >= 0; i-- {
+		if value, ok := strings.CutPrefix(entry.Env[i], "SHELL="); ok && value != "" {
+			return value
+		}
+	}
+
+	return DefaultShell
+}
@@ -0,0 +1,77 @@
+package crontab
+
+import (
+	"codnect.io/chrono"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func runEntry(t *testing.T, entry Entry, options ...chrono.Option) chrono.ExecutionRecord {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands are run with a POSIX shell")
+	}
+
+	scheduler := chrono.NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	task, err := scheduler.Schedule(entry.Task(), options...)
+	assert.Nil(t, err)
+
+	for i := 0; i < 50; i++ {
+		<-time.After(100 * time.Millisecond)
+
+		if history := task.(chrono.HistoryProvider).History(); len(history) != 0 {
+			return history[0]
+		}
+	}
+
+	t.Fatal("command has not been run")
+	return chrono.ExecutionRecord{}
+}
+
+func TestEntry_Task(t *testing.T) {
+	record := runEntry(t, Entry{
+		Command: `echo "$GREETING, $(cat)"; echo warning >&2`,
+		Input:   "world\n",
+		Env:     []string{"GREETING=hello"},
+	})
+
+	assert.Equal(t, chrono.ExecutionSucceeded, record.Outcome)
+	assert.Equal(t, chrono.CommandResult{
+		ExitCode: 0,
+		Stdout:   "hello, world\n",
+		Stderr:   "warning\n",
+	}, record.Result)
+}
+
+func TestEntry_TaskWithShell(t *testing.T) {
+	record := runEntry(t, Entry{Command: "echo $0", Env: []string{"SHELL=/bin/sh"}})
+	assert.Equal(t, "/bin/sh\n", record.Result.(chrono.CommandResult).Stdout)
+}
+
+func TestEntry_TaskWithNonZeroExitCode(t *testing.T) {
+	record := runEntry(t, Entry{Command: "echo failed >&2; exit 3"})
+
+	assert.Equal(t, chrono.ExecutionFailed, record.Outcome)
+	assert.EqualError(t, record.Err, "command exited with code 3")
+	assert.Equal(t, chrono.CommandResult{ExitCode: 3, Stderr: "failed\n"}, record.Result)
+}
+
+func TestEntry_TaskWithTimeout(t *testing.T) {
+	start := time.Now()
+	record := runEntry(t, Entry{Command: "sleep 5"}, chrono.WithTimeout(200*time.Millisecond))
+
+	assert.Less(t, time.Since(start), 3*time.Second)
+	assert.Equal(t, chrono.ExecutionFailed, record.Outcome)
+	assert.ErrorIs(t, record.Err, chrono.ErrExecutionTimeout)
+}
+
+func TestEntry_TaskWithBackgroundProcess(t *testing.T) {
+	start := time.Now()
+	record := runEntry(t, Entry{Command: "sleep 5 & sleep 5"}, chrono.WithTimeout(200*time.Millisecond))
+
+	assert.Less(t, time.Since(start), 3*time.Second)
+	assert.Equal(t, chrono.ExecutionFailed, record.Outcome)
+}
@@ -0,0 +1,280 @@
+package crontab
+
+import (
+	"bufio"
+	"codnect.io/chrono"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultShell = "/bin/sh"
+
+var (
+	envRegexp = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+	macros    = map[string]string{
+		"@yearly":   "0 0 0 1 1 *",
+		"@annually": "0 0 0 1 1 *",
+		"@monthly":  "0 0 0 1 * *",
+		"@weekly":   "0 0 0 * * 0",
+		"@daily":    "0 0 0 * * *",
+		"@midnight": "0 0 0 * * *",
+		"@hourly":   "0 0 * * * *",
+	}
+)
+
+// Entry is a command line of a crontab.
+type Entry struct {
+	// Line is the number of the line the entry is defined on, starting from one.
+	Line int
+	// Cron is the cron expression of the schedule with a seconds field, which is empty for @reboot.
+	Cron string
+	// Location is the time zone set by CRON_TZ, in which the schedule is evaluated.
+	Location string
+	// Reboot reports whether the command runs only once when it is scheduled.
+	Reboot  bool
+	Command string
+	// Input is the text after the first unescaped % of the command, which is written to its standard input.
+	Input string
+	// Env contains the variables assigned before the entry in the form of NAME=value.
+	Env []string
+}
+
+// Name returns the name the entry is scheduled with.
+func (entry Entry) Name() string {
+	return fmt.Sprintf("crontab:%d", entry.Line)
+}
+
+// JobDefinition returns the definition of the job running the entry, whose task is registered
+// with the name of the entry.
+func (entry Entry) JobDefinition() chrono.JobDefinition {
+	return chrono.JobDefinition{
+		Name:     entry.Name(),
+		Task:     entry.Name(),
+		Cron:     entry.Cron,
+		Location: entry.Location,
+	}
+}
+
+// Parse parses a crontab consisting of 5-field schedules followed by commands, the macros such as @daily and
+// @reboot, and the environment variable assignments, which apply to the entries after them. The schedule of an
+// entry is evaluated in the time zone set by CRON_TZ. The entries restricting both the day of month and the day of week
+// are rejected, since the system cron runs them if either matches, whereas a cron expression requires both to match.
+func Parse(reader io.Reader) ([]Entry, error) {
+	var (
+		entries  []Entry
+		env      []string
+		location string
+		line     int
+	)
+
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if match := envRegexp.FindStringSubmatch(text); match != nil {
+			name, value := match[1], unquote(strings.TrimSpace(match[2]))
+
+			if name == "CRON_TZ" {
+				if _, err := time.LoadLocation(value); err != nil {
+					return nil, fmt.Errorf("line %d : location not loaded : %s", line, value)
+				}
+
+				location = value
+				continue
+			}
+
+			env = append(env, name+"="+value)
+			continue
+		}
+
+		entry, err := parseEntry(text)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d : %w", line, err)
+		}
+
+		entry.Line = line
+		entry.Location = location
+		entry.Env = append([]string{}, env...)
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ParseFile parses the crontab in the given file.
+func ParseFile(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+	return Parse(file)
+}
+
+// Schedule registers the command of each entry as a task, and schedules their jobs on the scheduler.
+// The errors of the entries which cannot be scheduled are returned altogether.
+func Schedule(scheduler *chrono.SimpleTaskScheduler, entries []Entry) error {
+	var (
+		errs        []error
+		definitions []chrono.JobDefinition
+	)
+
+	for _, entry := range entries {
+		if err := scheduler.RegisterTask(entry.Name(), entry.Task()); err != nil {
+			errs = append(errs, fmt.Errorf("job %s : %w", entry.Name(), err))
+			continue
+		}
+
+		definitions = append(definitions, entry.JobDefinition())
+	}
+
+	if err := scheduler.ScheduleJobDefinitions(definitions); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// ScheduleFile schedules the entries of the crontab in the given file.
+func ScheduleFile(scheduler *chrono.SimpleTaskScheduler, path string) error {
+	entries, err := ParseFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	return Schedule(scheduler, entries)
+}
+
+func parseEntry(text string) (Entry, error) {
+	var entry Entry
+
+	if strings.HasPrefix(text, "@") {
+		macro, command := nextField(text)
+
+		if macro == "@reboot" {
+			entry.Reboot = true
+		} else if expression, ok := macros[macro]; ok {
+			entry.Cron = expression
+		} else {
+			return Entry{}, fmt.Errorf("unknown macro : %s", macro)
+		}
+
+		text = command
+	} else {
+		fields := make([]string, 0, 5)
+
+		for len(fields) < 5 && text != "" {
+			var field string
+			field, text = nextField(text)
+			fields = append(fields, field)
+		}
+
+		if len(fields) < 5 {
+			return Entry{}, fmt.Errorf("schedule must consist of 5 fields : found %d", len(fields))
+		}
+
+		if isRestricted(fields[2]) && isRestricted(fields[4]) {
+			return Entry{}, errors.New("day of month and day of week cannot be both restricted")
+		}
+
+		entry.Cron = "0 " + strings.Join(fields, " ")
+
+		if _, err := chrono.ParseCronExpression(entry.Cron); err != nil {
+			return Entry{}, err
+		}
+	}
+
+	if text == "" {
+		return Entry{}, errors.New("command cannot be empty")
+	}
+
+	entry.Command, entry.Input = splitCommand(text)
+	return entry, nil
+}
+
+// isRestricted reports whether a day field restricts the days, which is how the system cron decides to run
+// an entry if either of the day of month and the day of week matches.
+func isRestricted(field string) bool {
+	return !strings.HasPrefix(field, "*") && field != "?"
+}
+
+// nextField returns the first whitespace separated field of the text and the rest of it.
+func nextField(text string) (string, string) {
+	index := strings.IndexAny(text, " \t")
+
+	if index == -1 {
+		return text, ""
+	}
+
+	return text[:index], strings.TrimLeft(text[index:], " \t")
+}
+
+// splitCommand splits the command at the first unescaped %, and replaces the other unescaped
+// ones in the input with newlines.
+func splitCommand(text string) (string, string) {
+	var (
+		command strings.Builder
+		input   strings.Builder
+		inInput bool
+	)
+
+	for i := 0; i < len(text); i++ {
+		current := &command
+
+		if inInput {
+			current = &input
+		}
+
+		switch {
+		case text[i] == '\\' && i+1 < len(text) && text[i+1] == '%':
+			current.WriteByte('%')
+			i++
+		case text[i] == '%' && !inInput:
+			inInput = true
+		case text[i] == '%':
+			input.WriteByte('\n')
+		default:
+			current.WriteByte(text[i])
+		}
+	}
+
+	if inInput {
+		input.WriteByte('\n')
+	}
+
+	return command.String(), input.String()
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		if value[0] == '"' {
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				return unquoted
+			}
+		}
+
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
@@ -0,0 +1,126 @@
+package crontab
+
+import (
+	"codnect.io/chrono"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCrontab = `
+# system crontab
+SHELL=/bin/bash
+MAILTO="ops@example.com"
+PATH = /usr/local/bin:/usr/bin:/bin
+
+*/15 * * * *   /usr/bin/backup --incremental   >> /var/log/backup.log 2>&1
+0 9 * * mon-fri report.sh
+
+CRON_TZ=Europe/Istanbul
+@daily cleanup.sh
+@reboot warmup.sh
+30 2 1 jan * mail -s "yearly report" ops%Dear ops,%the report is ready.%
+0 0 * * 0 echo 100\% done
+`
+
+func TestParse(t *testing.T) {
+	entries, err := Parse(strings.NewReader(testCrontab))
+	assert.Nil(t, err)
+	assert.Len(t, entries, 6)
+
+	env := []string{"SHELL=/bin/bash", "MAILTO=ops@example.com", "PATH=/usr/local/bin:/usr/bin:/bin"}
+
+	assert.Equal(t, Entry{
+		Line:    7,
+		Cron:    "0 */15 * * * *",
+		Command: "/usr/bin/backup --incremental   >> /var/log/backup.log 2>&1",
+		Env:     env,
+	}, entries[0])
+
+	assert.Equal(t, "0 0 9 * * mon-fri", entries[1].Cron)
+	assert.Equal(t, "report.sh", entries[1].Command)
+	assert.Empty(t, entries[1].Location)
+
+	assert.Equal(t, Entry{
+		Line:     11,
+		Cron:     "0 0 0 * * *",
+		Location: "Europe/Istanbul",
+		Command:  "cleanup.sh",
+		Env:      env,
+	}, entries[2])
+
+	assert.True(t, entries[3].Reboot)
+	assert.Empty(t, entries[3].Cron)
+	assert.Equal(t, "warmup.sh", entries[3].Command)
+
+	assert.Equal(t, `mail -s "yearly report" ops`, entries[4].Command)
+	assert.Equal(t, "Dear ops,\nthe report is ready.\n\n", entries[4].Input)
+
+	assert.Equal(t, "echo 100% done", entries[5].Command)
+	assert.Empty(t, entries[5].Input)
+}
+
+func TestParse_InvalidLines(t *testing.T) {
+	testCases := []struct {
+		crontab string
+		err     string
+	}{
+		{"* * * *", "line 1 : schedule must consist of 5 fields : found 4"},
+		{"* * * * *", "line 1 : command cannot be empty"},
+		{"# comment\n61 * * * * backup", "line 2 : the value in field MINUTE must be between 0 and 59"},
+		{"@often backup", "line 1 : unknown macro : @often"},
+		{"@daily", "line 1 : command cannot be empty"},
+		{"CRON_TZ=Nowhere/City", "line 1 : location not loaded : Nowhere/City"},
+		{"0 0 1 * MON report", "line 1 : day of month and day of week cannot be both restricted"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := Parse(strings.NewReader(testCase.crontab))
+		assert.EqualError(t, err, testCase.err)
+	}
+}
+
+func TestEntry_JobDefinition(t *testing.T) {
+	entry := Entry{Line: 3, Cron: "0 0 0 * * *", Location: "UTC", Command: "cleanup.sh"}
+
+	assert.Equal(t, chrono.JobDefinition{
+		Name:     "crontab:3",
+		Task:     "crontab:3",
+		Cron:     "0 0 0 * * *",
+		Location: "UTC",
+	}, entry.JobDefinition())
+
+	job, err := entry.JobDefinition().Job()
+	assert.Nil(t, err)
+	assert.Equal(t, "0 0 0 * * *", job.Cron)
+
+	job, err = Entry{Line: 4, Reboot: true, Command: "warmup.sh"}.JobDefinition().Job()
+	assert.Nil(t, err)
+	assert.Empty(t, job.Cron)
+}
+
+func TestScheduleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	assert.Nil(t, os.WriteFile(path, []byte("@hourly true\n@reboot true\n"), 0o644))
+
+	scheduler := chrono.NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Nil(t, ScheduleFile(scheduler, path))
+
+	task, ok := scheduler.GetTask("crontab:1")
+	assert.True(t, ok)
+	assert.IsType(t, &chrono.TriggerTask{}, task)
+
+	_, ok = scheduler.GetTask("crontab:2")
+	assert.True(t, ok)
+
+	assert.EqualError(t, ScheduleFile(scheduler, path),
+		"job crontab:1 : task with name crontab:1 is already registered\n"+
+			"job crontab:2 : task with name crontab:2 is already registered")
+
+	_, err := ParseFile(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,536 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type debounceOptions struct {
+	leading  bool
+	trailing bool
+	maxWait  time.Duration
+}
+
+// DebounceOption configures a debouncer or a throttler.
+type DebounceOption func(options *debounceOptions) error
+
+// WithLeadingEdge sets whether the task runs on the first call. It is disabled for the debouncers and
+// enabled for the throttlers by default.
+func WithLeadingEdge(enabled bool) DebounceOption {
+	return func(options *debounceOptions) error {
+		options.leading = enabled
+		return nil
+	}
+}
+
+// WithTrailingEdge sets whether the task runs after the last call. It is enabled by default.
+func WithTrailingEdge(enabled bool) DebounceOption {
+	return func(options *debounceOptions) error {
+		options.trailing = enabled
+		return nil
+	}
+}
+
+// WithMaxWait sets the maximum time a call of a debouncer can be delayed while the calls keep coming.
+func WithMaxWait(maxWait time.Duration) DebounceOption {
+	return func(options *debounceOptions) error {
+		if maxWait <= 0 {
+			return errors.New("max wait must be greater than zero")
+		}
+
+		options.maxWait = maxWait
+		return nil
+	}
+}
+
+func newDebounceOptions(leading bool, options []DebounceOption) (debounceOptions, error) {
+	debounceOptions := debounceOptions{leading: leading, trailing: true}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err := option(&debounceOptions); err != nil {
+			return debounceOptions, err
+		}
+	}
+
+	if !debounceOptions.leading && !debounceOptions.trailing {
+		return debounceOptions, errors.New("leading and trailing edges cannot be both disabled")
+	}
+
+	return debounceOptions, nil
+}
+
+// limiterState is the state of a debouncer or a throttler for a key.
+type limiterState struct {
+	// lastCallTime is the time of the last call of a debouncer.
+	lastCallTime time.Time
+	// burstStartTime is the time the current burst of the calls of a debouncer has started, or the time it has
+	// last run at. The pending run of the burst is not delayed further than the max wait after it.
+	burstStartTime time.Time
+	// windowEndTime is the time the task of a throttler can run again at.
+	windowEndTime time.Time
+	// scheduledTask is the pending run replaced by the later calls, and leadingTask is the pending run of
+	// the leading edge, which is not replaced by them.
+	scheduledTask ScheduledTask
+	leadingTask   ScheduledTask
+	generation    int
+}
+
+// keyedLimiter keeps the states of the keys, and submits the runs of the task to the executor.
+type keyedLimiter[K comparable] struct {
+	executor   TaskExecutor
+	task       func(ctx context.Context, key K)
+	options    debounceOptions
+	idleAfter  time.Duration
+	states     map[K]*limiterState
+	prunedSize int
+	limiterMu  sync.Mutex
+}
+
+func newKeyedLimiter[K comparable](executor TaskExecutor, task func(ctx context.Context, key K), options debounceOptions) (*keyedLimiter[K], error) {
+	if executor == nil {
+		return nil, errors.New("executor cannot be nil")
+	}
+
+	if task == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+
+	return &keyedLimiter[K]{
+		executor: executor,
+		task:     task,
+		options:  options,
+		states:   make(map[K]*limiterState),
+	}, nil
+}
+
+// state returns the state of the key, and removes the states of the idle keys once the states are doubled
+// since they have been last pruned.
+func (limiter *keyedLimiter[K]) state(key K, now time.Time) *limiterState {
+	state, ok := limiter.states[key]
+
+	if ok {
+		return state
+	}
+
+	if len(limiter.states) >= 2*limiter.prunedSize {
+		for otherKey, otherState := range limiter.states {
+			if limiter.isIdle(otherState, now) {
+				delete(limiter.states, otherKey)
+			}
+		}
+
+		limiter.prunedSize = len(limiter.states)
+	}
+
+	state = &limiterState{}
+	limiter.states[key] = state
+	return state
+}
+
+func (limiter *keyedLimiter[K]) isIdle(state *limiterState, now time.Time) bool {
+	return state.scheduledTask == nil && state.leadingTask == nil && !now.Before(state.lastCallTime.Add(limiter.idleAfter)) && !now.Before(state.windowEndTime)
+}
+
+// run submits the task to be run for the key after the delay, replacing the run already submitted.
+func (limiter *keyedLimiter[K]) run(key K, state *limiterState, delay time.Duration, onRun func(state *limiterState, now time.Time)) error {
+	limiter.unschedule(state)
+
+	generation := state.generation
+
+	scheduledTask, err := limiter.executor.Schedule(func(ctx context.Context) {
+		limiter.limiterMu.Lock()
+
+		// the run has been replaced or cancelled after it is started
+		if state.generation != generation {
+			limiter.limiterMu.Unlock()
+			return
+		}
+
+		state.scheduledTask = nil
+		onRun(state, time.Now())
+		limiter.limiterMu.Unlock()
+
+		limiter.task(ctx, key)
+	}, delay)
+
+	if err != nil {
+		return err
+	}
+
+	state.scheduledTask = scheduledTask
+	return nil
+}
+
+// runNow submits the task to be run for the key at once. The run is not replaced by the later calls,
+// since it is the leading edge of them.
+func (limiter *keyedLimiter[K]) runNow(key K, state *limiterState, onRun func(state *limiterState, now time.Time)) error {
+	var scheduledTask ScheduledTask
+
+	scheduledTask, err := limiter.executor.Schedule(func(ctx context.Context) {
+		limiter.limiterMu.Lock()
+
+		// the run has been cancelled after it is started
+		if limiter.states[key] != state {
+			limiter.limiterMu.Unlock()
+			return
+		}
+
+		if state.leadingTask == scheduledTask {
+			state.leadingTask = nil
+		}
+
+		onRun(state, time.Now())
+		limiter.limiterMu.Unlock()
+
+		limiter.task(ctx, key)
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	state.leadingTask = scheduledTask
+	return nil
+}
+
+func (limiter *keyedLimiter[K]) unschedule(state *limiterState) {
+	state.generation++
+
+	if state.scheduledTask != nil {
+		state.scheduledTask.Cancel()
+		state.scheduledTask = nil
+	}
+}
+
+// remove cancels the pending runs for the key including the leading one, and removes its state.
+func (limiter *keyedLimiter[K]) remove(key K, state *limiterState) {
+	limiter.unschedule(state)
+
+	if state.leadingTask != nil {
+		state.leadingTask.Cancel()
+		state.leadingTask = nil
+	}
+
+	delete(limiter.states, key)
+}
+
+func (limiter *keyedLimiter[K]) cancel(key K) {
+	limiter.limiterMu.Lock()
+	defer limiter.limiterMu.Unlock()
+
+	if state, ok := limiter.states[key]; ok {
+		limiter.remove(key, state)
+	}
+}
+
+func (limiter *keyedLimiter[K]) cancelAll() {
+	limiter.limiterMu.Lock()
+	defer limiter.limiterMu.Unlock()
+
+	for key, state := range limiter.states {
+		limiter.remove(key, state)
+	}
+}
+
+func (limiter *keyedLimiter[K]) pending(key K) bool {
+	limiter.limiterMu.Lock()
+	defer limiter.limiterMu.Unlock()
+
+	state, ok := limiter.states[key]
+
+	if !ok || limiter.executor.IsShutdown() {
+		return false
+	}
+
+	return isPending(state.scheduledTask) || isPending(state.leadingTask)
+}
+
+func isPending(scheduledTask ScheduledTask) bool {
+	return scheduledTask != nil && !scheduledTask.IsCancelled()
+}
+
+func (limiter *keyedLimiter[K]) checkShutdown() error {
+	if limiter.executor.IsShutdown() {
+		return errors.New("call cannot be accepted because executor is already shut down")
+	}
+
+	return nil
+}
+
+// KeyedDebouncer delays the runs of a task for each key until the calls for the key stop for a while,
+// such as debouncing the notifications of each customer.
+type KeyedDebouncer[K comparable] struct {
+	limiter *keyedLimiter[K]
+	wait    time.Duration
+}
+
+// NewKeyedDebouncer returns a debouncer running the task through the executor once the given time passes since
+// the last call for a key.
+func NewKeyedDebouncer[K comparable](executor TaskExecutor, wait time.Duration, task func(ctx context.Context, key K),
+	options ...DebounceOption) (*KeyedDebouncer[K], error) {
+	if wait <= 0 {
+		return nil, errors.New("wait must be greater than zero")
+	}
+
+	debounceOptions, err := newDebounceOptions(false, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if debounceOptions.maxWait != 0 && debounceOptions.maxWait < wait {
+		return nil, errors.New("max wait cannot be less than wait")
+	}
+
+	limiter, err := newKeyedLimiter(executor, task, debounceOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	limiter.idleAfter = wait
+
+	return &KeyedDebouncer[K]{
+		limiter: limiter,
+		wait:    wait,
+	}, nil
+}
+
+// Call calls the debouncer for the key. The task runs at once if it is the first call of a burst and the leading
+// edge is enabled. Otherwise, its run is delayed until no other call is made for the wait time, or the max wait
+// passes since the burst has started or the task has last run.
+func (debouncer *KeyedDebouncer[K]) Call(key K) error {
+	limiter := debouncer.limiter
+
+	if err := limiter.checkShutdown(); err != nil {
+		return err
+	}
+
+	limiter.limiterMu.Lock()
+	defer limiter.limiterMu.Unlock()
+
+	now := time.Now()
+	state := limiter.state(key, now)
+
+	newBurst := state.lastCallTime.IsZero() || !now.Before(state.lastCallTime.Add(debouncer.wait))
+	state.lastCallTime = now
+
+	onRun := func(state *limiterState, now time.Time) {
+		state.burstStartTime = now
+	}
+
+	if newBurst {
+		state.burstStartTime = now
+
+		if limiter.options.leading {
+			return limiter.runNow(key, state, onRun)
+		}
+	}
+
+	var runTime time.Time
+
+	if limiter.options.trailing {
+		runTime = now.Add(debouncer.wait)
+	}
+
+	if maxWait := limiter.options.maxWait; maxWait != 0 {
+		if maxWaitTime := state.burstStartTime.Add(maxWait); runTime.IsZero() || maxWaitTime.Before(runTime) {
+			runTime = maxWaitTime
+		}
+	}
+
+	if runTime.IsZero() {
+		return nil
+	}
+
+	return limiter.run(key, state, runTime.Sub(now), onRun)
+}
+
+// Cancel cancels the pending run for the key, and the next call for the key starts a new burst.
+func (debouncer *KeyedDebouncer[K]) Cancel(key K) {
+	debouncer.limiter.cancel(key)
+}
+
+// CancelAll cancels the pending runs for all the keys.
+func (debouncer *KeyedDebouncer[K]) CancelAll() {
+	debouncer.limiter.cancelAll()
+}
+
+// Pending reports whether a run for the key is waiting to be started.
+func (debouncer *KeyedDebouncer[K]) Pending(key K) bool {
+	return debouncer.limiter.pending(key)
+}
+
+// Debouncer delays the runs of a task until the calls stop for a while.
+type Debouncer struct {
+	debouncer *KeyedDebouncer[struct{}]
+}
+
+// NewDebouncer returns a debouncer running the task through the executor once the given time passes since
+// the last call.
+func NewDebouncer(executor TaskExecutor, wait time.Duration, task Task, options ...DebounceOption) (*Debouncer, error) {
+	if task == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+
+	debouncer, err := NewKeyedDebouncer(executor, wait, func(ctx context.Context, key struct{}) {
+		task(ctx)
+	}, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Debouncer{debouncer: debouncer}, nil
+}
+
+// Call calls the debouncer. The task runs at once if it is the first call of a burst and the leading edge
+// is enabled. Otherwise, its run is delayed until no other call is made for the wait time, or the max wait passes.
+func (debouncer *Debouncer) Call() error {
+	return debouncer.debouncer.Call(struct{}{})
+}
+
+// Cancel cancels the pending run, and the next call starts a new burst.
+func (debouncer *Debouncer) Cancel() {
+	debouncer.debouncer.Cancel(struct{}{})
+}
+
+// Pending reports whether a run is waiting to be started.
+func (debouncer *Debouncer) Pending() bool {
+	return debouncer.debouncer.Pending(struct{}{})
+}
+
+// KeyedThrottler runs a task at most once in an interval for each key.
+type KeyedThrottler[K comparable] struct {
+	limiter  *keyedLimiter[K]
+	interval time.Duration
+}
+
+// NewKeyedThrottler returns a throttler running the task through the executor at most once in the given interval
+// for a key. The max wait cannot be used with a throttler.
+func NewKeyedThrottler[K comparable](executor TaskExecutor, interval time.Duration, task func(ctx context.Context, key K),
+	options ...DebounceOption) (*KeyedThrottler[K], error) {
+	if interval <= 0 {
+		return nil, errors.New("interval must be greater than zero")
+	}
+
+	debounceOptions, err := newDebounceOptions(true, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if debounceOptions.maxWait != 0 {
+		return nil, errors.New("max wait cannot be used with a throttler")
+	}
+
+	limiter, err := newKeyedLimiter(executor, task, debounceOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyedThrottler[K]{
+		limiter:  limiter,
+		interval: interval,
+	}, nil
+}
+
+// Call calls the throttler for the key. The task runs at once if it has not run in the last interval and
+// the leading edge is enabled. Otherwise, a run is submitted for the end of the interval if the trailing edge
+// is enabled, and the call is dropped if a run is already pending.
+func (throttler *KeyedThrottler[K]) Call(key K) error {
+	limiter := throttler.limiter
+
+	if err := limiter.checkShutdown(); err != nil {
+		return err
+	}
+
+	limiter.limiterMu.Lock()
+	defer limiter.limiterMu.Unlock()
+
+	now := time.Now()
+	state := limiter.state(key, now)
+
+	if isPending(state.scheduledTask) {
+		return nil
+	}
+
+	onRun := func(state *limiterState, now time.Time) {
+		state.windowEndTime = now.Add(throttler.interval)
+	}
+
+	if !now.Before(state.windowEndTime) {
+		if limiter.options.leading {
+			state.windowEndTime = now.Add(throttler.interval)
+			return limiter.runNow(key, state, onRun)
+		}
+
+		return limiter.run(key, state, throttler.interval, onRun)
+	}
+
+	if !limiter.options.trailing {
+		return nil
+	}
+
+	return limiter.run(key, state, state.windowEndTime.Sub(now), onRun)
+}
+
+// Cancel cancels the pending run for the key, and resets its interval.
+func (throttler *KeyedThrottler[K]) Cancel(key K) {
+	throttler.limiter.cancel(key)
+}
+
+// CancelAll cancels the pending runs for all the keys.
+func (throttler *KeyedThrottler[K]) CancelAll() {
+	throttler.limiter.cancelAll()
+}
+
+// Pending reports whether a run for the key is waiting to be started.
+func (throttler *KeyedThrottler[K]) Pending(key K) bool {
+	return throttler.limiter.pending(key)
+}
+
+// Throttler runs a task at most once in an interval.
+type Throttler struct {
+	throttler *KeyedThrottler[struct{}]
+}
+
+// NewThrottler returns a throttler running the task through the executor at most once in the given interval.
+func NewThrottler(executor TaskExecutor, interval time.Duration, task Task, options ...DebounceOption) (*Throttler, error) {
+	if task == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+
+	throttler, err := NewKeyedThrottler(executor, interval, func(ctx context.Context, key struct{}) {
+		task(ctx)
+	}, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Throttler{throttler: throttler}, nil
+}
+
+// Call calls the throttler. The task runs at once if it has not run in the last interval and the leading edge
+// is enabled. Otherwise, a run is submitted for the end of the interval if the trailing edge is enabled.
+func (throttler *Throttler) Call() error {
+	return throttler.throttler.Call(struct{}{})
+}
+
+// Cancel cancels the pending run, and resets the interval.
+func (throttler *Throttler) Cancel() {
+	throttler.throttler.Cancel(struct{}{})
+}
+
+// Pending reports whether a run is waiting to be started.
+func (throttler *Throttler) Pending() bool {
+	return throttler.throttler.Pending(struct{}{})
+}
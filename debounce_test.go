@@ -0,0 +1,397 @@
+package chrono
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type keyedRuns[K comparable] struct {
+	runs   map[K][]time.Time
+	runsMu sync.Mutex
+}
+
+func newKeyedRuns[K comparable]() *keyedRuns[K] {
+	return &keyedRuns[K]{runs: make(map[K][]time.Time)}
+}
+
+func (runs *keyedRuns[K]) task(ctx context.Context, key K) {
+	runs.runsMu.Lock()
+	defer runs.runsMu.Unlock()
+	runs.runs[key] = append(runs.runs[key], time.Now())
+}
+
+func (runs *keyedRuns[K]) get(key K) []time.Time {
+	runs.runsMu.Lock()
+	defer runs.runsMu.Unlock()
+	return append([]time.Time{}, runs.runs[key]...)
+}
+
+func callEvery(t *testing.T, call func() error, count int, interval time.Duration) time.Time {
+	var lastCallTime time.Time
+
+	for i := 0; i < count; i++ {
+		if i != 0 {
+			<-time.After(interval)
+		}
+
+		lastCallTime = time.Now()
+		assert.Nil(t, call())
+	}
+
+	return lastCallTime
+}
+
+func TestNewDebouncer_InvalidOptions(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	task := func(ctx context.Context) {}
+
+	_, err := NewDebouncer(nil, time.Second, task)
+	assert.EqualError(t, err, "executor cannot be nil")
+
+	_, err = NewDebouncer(executor, time.Second, nil)
+	assert.EqualError(t, err, "task cannot be nil")
+
+	_, err = NewDebouncer(executor, 0, task)
+	assert.EqualError(t, err, "wait must be greater than zero")
+
+	_, err = NewDebouncer(executor, time.Second, task, WithMaxWait(0))
+	assert.EqualError(t, err, "max wait must be greater than zero")
+
+	_, err = NewDebouncer(executor, time.Second, task, WithMaxWait(time.Millisecond))
+	assert.EqualError(t, err, "max wait cannot be less than wait")
+
+	_, err = NewDebouncer(executor, time.Second, task, WithLeadingEdge(false), WithTrailingEdge(false))
+	assert.EqualError(t, err, "leading and trailing edges cannot be both disabled")
+
+	_, err = NewThrottler(executor, 0, task)
+	assert.EqualError(t, err, "interval must be greater than zero")
+
+	_, err = NewThrottler(executor, time.Second, task, WithMaxWait(time.Second))
+	assert.EqualError(t, err, "max wait cannot be used with a throttler")
+
+	_, err = NewThrottler(executor, time.Second, nil)
+	assert.EqualError(t, err, "task cannot be nil")
+}
+
+func TestDebouncer_Call(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[struct{}]()
+	debouncer, err := NewDebouncer(executor, 100*time.Millisecond, func(ctx context.Context) {
+		runs.task(ctx, struct{}{})
+	})
+	assert.Nil(t, err)
+
+	lastCallTime := callEvery(t, debouncer.Call, 5, 50*time.Millisecond)
+	assert.Empty(t, runs.get(struct{}{}))
+	assert.True(t, debouncer.Pending())
+
+	<-time.After(150 * time.Millisecond)
+
+	times := runs.get(struct{}{})
+	assert.Len(t, times, 1)
+	assert.False(t, debouncer.Pending())
+
+	if len(times) == 1 {
+		assert.GreaterOrEqual(t, times[0].Sub(lastCallTime), 100*time.Millisecond)
+	}
+}
+
+func TestDebouncer_CallWithLeadingEdge(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[string]()
+	debouncer, err := NewKeyedDebouncer(executor, 100*time.Millisecond, runs.task, WithLeadingEdge(true))
+	assert.Nil(t, err)
+
+	assert.Nil(t, debouncer.Call("single"))
+	<-time.After(50 * time.Millisecond)
+	assert.Len(t, runs.get("single"), 1)
+
+	callEvery(t, func() error { return debouncer.Call("burst") }, 3, 30*time.Millisecond)
+	<-time.After(20 * time.Millisecond)
+	assert.Len(t, runs.get("burst"), 1)
+
+	<-time.After(150 * time.Millisecond)
+	assert.Len(t, runs.get("single"), 1)
+	assert.Len(t, runs.get("burst"), 2)
+}
+
+func TestDebouncer_CallWithLeadingEdgeOnly(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[int]()
+	debouncer, err := NewKeyedDebouncer(executor, 100*time.Millisecond, runs.task,
+		WithLeadingEdge(true), WithTrailingEdge(false))
+	assert.Nil(t, err)
+
+	callEvery(t, func() error { return debouncer.Call(1) }, 5, 50*time.Millisecond)
+	<-time.After(150 * time.Millisecond)
+	assert.Len(t, runs.get(1), 1)
+
+	assert.Nil(t, debouncer.Call(1))
+	<-time.After(50 * time.Millisecond)
+	assert.Len(t, runs.get(1), 2)
+}
+
+func TestDebouncer_CallWithMaxWait(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[struct{}]()
+	debouncer, err := NewDebouncer(executor, 100*time.Millisecond, func(ctx context.Context) {
+		runs.task(ctx, struct{}{})
+	}, WithMaxWait(200*time.Millisecond))
+	assert.Nil(t, err)
+
+	start := time.Now()
+	callEvery(t, debouncer.Call, 10, 50*time.Millisecond)
+	<-time.After(150 * time.Millisecond)
+
+	times := runs.get(struct{}{})
+	assert.Len(t, times, 3)
+
+	if len(times) == 3 {
+		assert.InDelta(t, 200*time.Millisecond, times[0].Sub(start), float64(40*time.Millisecond))
+		assert.InDelta(t, 400*time.Millisecond, times[1].Sub(start), float64(40*time.Millisecond))
+	}
+}
+
+func TestKeyedDebouncer_Call(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[string]()
+	debouncer, err := NewKeyedDebouncer(executor, 100*time.Millisecond, runs.task)
+	assert.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, debouncer.Call("customer-1"))
+		assert.Nil(t, debouncer.Call("customer-2"))
+		<-time.After(50 * time.Millisecond)
+	}
+
+	assert.Nil(t, debouncer.Call("customer-1"))
+	assert.True(t, debouncer.Pending("customer-1"))
+	assert.True(t, debouncer.Pending("customer-2"))
+	assert.False(t, debouncer.Pending("customer-3"))
+
+	<-time.After(80 * time.Millisecond)
+	assert.Len(t, runs.get("customer-1"), 0)
+	assert.Len(t, runs.get("customer-2"), 1)
+
+	<-time.After(50 * time.Millisecond)
+	assert.Len(t, runs.get("customer-1"), 1)
+}
+
+func TestKeyedDebouncer_Cancel(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[string]()
+	debouncer, err := NewKeyedDebouncer(executor, 50*time.Millisecond, runs.task)
+	assert.Nil(t, err)
+
+	assert.Nil(t, debouncer.Call("a"))
+	assert.Nil(t, debouncer.Call("b"))
+	assert.Nil(t, debouncer.Call("c"))
+
+	debouncer.Cancel("a")
+	assert.False(t, debouncer.Pending("a"))
+	assert.True(t, debouncer.Pending("b"))
+
+	<-time.After(100 * time.Millisecond)
+	assert.Empty(t, runs.get("a"))
+	assert.Len(t, runs.get("b"), 1)
+	assert.Len(t, runs.get("c"), 1)
+
+	assert.Nil(t, debouncer.Call("a"))
+	assert.Nil(t, debouncer.Call("b"))
+	debouncer.CancelAll()
+
+	<-time.After(100 * time.Millisecond)
+	assert.Empty(t, runs.get("a"))
+	assert.Len(t, runs.get("b"), 1)
+}
+
+func TestKeyedDebouncer_CancelLeadingRun(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[string]()
+	debouncer, err := NewKeyedDebouncer(executor, 50*time.Millisecond, runs.task, WithLeadingEdge(true))
+	assert.Nil(t, err)
+
+	executor.(Pausable).Pause()
+	<-time.After(20 * time.Millisecond)
+
+	assert.Nil(t, debouncer.Call("a"))
+	assert.Nil(t, debouncer.Call("b"))
+	<-time.After(20 * time.Millisecond)
+
+	assert.True(t, debouncer.Pending("a"))
+	assert.True(t, debouncer.Pending("b"))
+
+	debouncer.Cancel("a")
+	assert.False(t, debouncer.Pending("a"))
+
+	executor.(Pausable).Resume()
+	<-time.After(50 * time.Millisecond)
+
+	assert.Empty(t, runs.get("a"))
+	assert.Len(t, runs.get("b"), 1)
+	assert.False(t, debouncer.Pending("b"))
+}
+
+func TestKeyedDebouncer_PruneIdleKeys(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[int]()
+	debouncer, err := NewKeyedDebouncer(executor, 10*time.Millisecond, runs.task)
+	assert.Nil(t, err)
+
+	for key := 0; key < 10; key++ {
+		assert.Nil(t, debouncer.Call(key))
+	}
+
+	<-time.After(100 * time.Millisecond)
+
+	// the idle keys are pruned once the number of the keys is doubled since the last pruning
+	for key := 10; key <= 16; key++ {
+		assert.Nil(t, debouncer.Call(key))
+	}
+
+	debouncer.limiter.limiterMu.Lock()
+	assert.Len(t, debouncer.limiter.states, 7)
+	debouncer.limiter.limiterMu.Unlock()
+}
+
+func TestDebouncer_Shutdown(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+
+	runs := newKeyedRuns[struct{}]()
+	debouncer, err := NewDebouncer(executor, 50*time.Millisecond, func(ctx context.Context) {
+		runs.task(ctx, struct{}{})
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, debouncer.Call())
+	<-executor.Shutdown()
+
+	assert.False(t, debouncer.Pending())
+	assert.EqualError(t, debouncer.Call(), "call cannot be accepted because executor is already shut down")
+
+	<-time.After(100 * time.Millisecond)
+	assert.Empty(t, runs.get(struct{}{}))
+}
+
+func TestThrottler_Call(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[struct{}]()
+	throttler, err := NewThrottler(executor, 100*time.Millisecond, func(ctx context.Context) {
+		runs.task(ctx, struct{}{})
+	})
+	assert.Nil(t, err)
+
+	start := time.Now()
+	callEvery(t, throttler.Call, 8, 30*time.Millisecond)
+	<-time.After(150 * time.Millisecond)
+
+	times := runs.get(struct{}{})
+	assert.Len(t, times, 4)
+
+	if len(times) == 4 {
+		assert.Less(t, times[0].Sub(start), 20*time.Millisecond)
+
+		for i := 1; i < len(times); i++ {
+			assert.GreaterOrEqual(t, times[i].Sub(times[i-1]), 95*time.Millisecond)
+		}
+	}
+}
+
+func TestThrottler_CallWithoutTrailingEdge(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[struct{}]()
+	throttler, err := NewThrottler(executor, 100*time.Millisecond, func(ctx context.Context) {
+		runs.task(ctx, struct{}{})
+	}, WithTrailingEdge(false))
+	assert.Nil(t, err)
+
+	callEvery(t, throttler.Call, 3, 20*time.Millisecond)
+	assert.False(t, throttler.Pending())
+
+	<-time.After(150 * time.Millisecond)
+	assert.Len(t, runs.get(struct{}{}), 1)
+
+	assert.Nil(t, throttler.Call())
+	<-time.After(20 * time.Millisecond)
+	assert.Len(t, runs.get(struct{}{}), 2)
+}
+
+func TestThrottler_CallWithoutLeadingEdge(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[struct{}]()
+	throttler, err := NewThrottler(executor, 100*time.Millisecond, func(ctx context.Context) {
+		runs.task(ctx, struct{}{})
+	}, WithLeadingEdge(false))
+	assert.Nil(t, err)
+
+	start := time.Now()
+	assert.Nil(t, throttler.Call())
+	assert.Nil(t, throttler.Call())
+	assert.True(t, throttler.Pending())
+
+	<-time.After(150 * time.Millisecond)
+
+	times := runs.get(struct{}{})
+	assert.Len(t, times, 1)
+
+	if len(times) == 1 {
+		assert.GreaterOrEqual(t, times[0].Sub(start), 100*time.Millisecond)
+	}
+}
+
+func TestKeyedThrottler_Call(t *testing.T) {
+	executor := NewDefaultTaskExecutor()
+	defer func() { <-executor.Shutdown() }()
+
+	runs := newKeyedRuns[string]()
+	throttler, err := NewKeyedThrottler(executor, 100*time.Millisecond, runs.task)
+	assert.Nil(t, err)
+
+	assert.Nil(t, throttler.Call("a"))
+	assert.Nil(t, throttler.Call("b"))
+	assert.Nil(t, throttler.Call("a"))
+
+	<-time.After(50 * time.Millisecond)
+	assert.Len(t, runs.get("a"), 1)
+	assert.Len(t, runs.get("b"), 1)
+	assert.True(t, throttler.Pending("a"))
+	assert.False(t, throttler.Pending("b"))
+
+	throttler.Cancel("a")
+	<-time.After(100 * time.Millisecond)
+	assert.Len(t, runs.get("a"), 1)
+
+	assert.Nil(t, throttler.Call("a"))
+	<-time.After(20 * time.Millisecond)
+	assert.Nil(t, throttler.Call("a"))
+	throttler.CancelAll()
+	<-time.After(150 * time.Millisecond)
+	assert.Len(t, runs.get("a"), 2)
+}
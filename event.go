@@ -0,0 +1,266 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// idleEventDelay is how far the next execution time of an event trigger is put when there is neither
+// a pending event nor a scheduled execution, so that the task waits for the next event.
+const idleEventDelay = 100 * 365 * 24 * time.Hour
+
+// EventSource notifies the event triggers of the events such as the values received from a channel.
+type EventSource interface {
+	// Listen calls the given function each time an event occurs until the context is cancelled.
+	Listen(ctx context.Context, fire func())
+}
+
+type channelEventSource[T any] struct {
+	channel <-chan T
+}
+
+// ChannelEvents returns an event source firing an event for each value received from the channel
+// until it is closed.
+func ChannelEvents[T any](channel <-chan T) EventSource {
+	return channelEventSource[T]{channel: channel}
+}
+
+func (source channelEventSource[T]) Listen(ctx context.Context, fire func()) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-source.channel:
+			if !ok {
+				return
+			}
+
+			fire()
+		}
+	}
+}
+
+type fileEventSource struct {
+	path         string
+	pollInterval time.Duration
+}
+
+// FileEvents returns an event source polling the file at the given path, which fires an event each time
+// the file appears. The file existing when the source starts to be listened is also an event.
+func FileEvents(path string, pollInterval time.Duration) EventSource {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return fileEventSource{path: path, pollInterval: pollInterval}
+}
+
+func (source fileEventSource) Listen(ctx context.Context, fire func()) {
+	ticker := time.NewTicker(source.pollInterval)
+	defer ticker.Stop()
+
+	exists := false
+
+	for {
+		_, err := os.Stat(source.path)
+
+		if err == nil && !exists {
+			fire()
+		}
+
+		exists = err == nil
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Signal is an event source whose events are fired by the application.
+type Signal struct {
+	listeners   map[*func()]struct{}
+	listenersMu sync.Mutex
+}
+
+func NewSignal() *Signal {
+	return &Signal{
+		listeners: make(map[*func()]struct{}),
+	}
+}
+
+// Fire fires an event to all the triggers listening to the signal.
+func (signal *Signal) Fire() {
+	signal.listenersMu.Lock()
+	listeners := make([]func(), 0, len(signal.listeners))
+
+	for listener := range signal.listeners {
+		listeners = append(listeners, *listener)
+	}
+
+	signal.listenersMu.Unlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+func (signal *Signal) Listen(ctx context.Context, fire func()) {
+	signal.listenersMu.Lock()
+	signal.listeners[&fire] = struct{}{}
+	signal.listenersMu.Unlock()
+
+	<-ctx.Done()
+
+	signal.listenersMu.Lock()
+	delete(signal.listeners, &fire)
+	signal.listenersMu.Unlock()
+}
+
+type EventTriggerOption func(trigger *EventTrigger) error
+
+// WithEventDebounce delays the execution triggered by an event until no other event occurs for the given duration.
+func WithEventDebounce(debounce time.Duration) EventTriggerOption {
+	return func(trigger *EventTrigger) error {
+		if debounce < 0 {
+			return errors.New("debounce cannot be negative")
+		}
+
+		trigger.debounce = debounce
+		return nil
+	}
+}
+
+// WithEventThrottle delays the execution triggered by an event until the given duration passes since
+// the last execution of the task.
+func WithEventThrottle(throttle time.Duration) EventTriggerOption {
+	return func(trigger *EventTrigger) error {
+		if throttle < 0 {
+			return errors.New("throttle cannot be negative")
+		}
+
+		trigger.throttle = throttle
+		return nil
+	}
+}
+
+// WithEventSchedule runs the task also at the execution times of the given trigger such as a cron trigger.
+func WithEventSchedule(schedule Trigger) EventTriggerOption {
+	return func(trigger *EventTrigger) error {
+		if schedule == nil {
+			return errors.New("schedule cannot be nil")
+		}
+
+		trigger.schedule = schedule
+		return nil
+	}
+}
+
+// EventTrigger is a trigger running a task in response to the events of an event source. An event is pending
+// until the task is run after it occurs, and the events occurring while the task is running make it run again.
+// An event trigger can be used by a single task.
+type EventTrigger struct {
+	source        EventSource
+	debounce      time.Duration
+	throttle      time.Duration
+	schedule      Trigger
+	lastEventTime time.Time
+	cancel        context.CancelFunc
+	triggerMu     sync.Mutex
+}
+
+func NewEventTrigger(source EventSource, options ...EventTriggerOption) (*EventTrigger, error) {
+	if source == nil {
+		return nil, errors.New("event source cannot be nil")
+	}
+
+	trigger := &EventTrigger{
+		source: source,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err := option(trigger); err != nil {
+			return nil, err
+		}
+	}
+
+	return trigger, nil
+}
+
+// NextExecutionTime returns the time the pending event is run at, or the next execution time of the schedule
+// if it is earlier.
+func (trigger *EventTrigger) NextExecutionTime(ctx TriggerContext) time.Time {
+	trigger.triggerMu.Lock()
+	lastEventTime := trigger.lastEventTime
+	trigger.triggerMu.Unlock()
+
+	var next time.Time
+
+	if trigger.schedule != nil {
+		next = trigger.schedule.NextExecutionTime(ctx)
+	}
+
+	now := time.Now()
+	lastExecutionTime := ctx.LastExecutionTime()
+
+	if !lastEventTime.IsZero() && lastEventTime.After(lastExecutionTime) {
+		eventTime := lastEventTime.Add(trigger.debounce)
+
+		if throttled := lastExecutionTime.Add(trigger.throttle); !lastExecutionTime.IsZero() && eventTime.Before(throttled) {
+			eventTime = throttled
+		}
+
+		if eventTime.Before(now) {
+			eventTime = now
+		}
+
+		if next.IsZero() || eventTime.Before(next) {
+			next = eventTime
+		}
+	}
+
+	if next.IsZero() {
+		return now.Add(idleEventDelay)
+	}
+
+	return next
+}
+
+// start listens to the event source, and calls the given function after each event.
+func (trigger *EventTrigger) start(wake func()) {
+	trigger.triggerMu.Lock()
+	defer trigger.triggerMu.Unlock()
+
+	if trigger.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	trigger.cancel = cancel
+
+	go trigger.source.Listen(ctx, func() {
+		trigger.triggerMu.Lock()
+		trigger.lastEventTime = time.Now()
+		trigger.triggerMu.Unlock()
+
+		wake()
+	})
+}
+
+func (trigger *EventTrigger) stop() {
+	trigger.triggerMu.Lock()
+	defer trigger.triggerMu.Unlock()
+
+	if trigger.cancel != nil {
+		trigger.cancel()
+		trigger.cancel = nil
+	}
+}
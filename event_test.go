@@ -0,0 +1,308 @@
+package chrono
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type executionTimes struct {
+	times   []time.Time
+	timesMu sync.Mutex
+}
+
+func (executionTimes *executionTimes) task(ctx context.Context) {
+	executionTimes.timesMu.Lock()
+	defer executionTimes.timesMu.Unlock()
+	executionTimes.times = append(executionTimes.times, time.Now())
+}
+
+func (executionTimes *executionTimes) get() []time.Time {
+	executionTimes.timesMu.Lock()
+	defer executionTimes.timesMu.Unlock()
+	return append([]time.Time{}, executionTimes.times...)
+}
+
+func TestNewEventTrigger_InvalidOptions(t *testing.T) {
+	_, err := NewEventTrigger(nil)
+	assert.EqualError(t, err, "event source cannot be nil")
+
+	_, err = NewEventTrigger(NewSignal(), WithEventDebounce(-time.Second))
+	assert.EqualError(t, err, "debounce cannot be negative")
+
+	_, err = NewEventTrigger(NewSignal(), WithEventThrottle(-time.Second))
+	assert.EqualError(t, err, "throttle cannot be negative")
+
+	_, err = NewEventTrigger(NewSignal(), WithEventSchedule(nil))
+	assert.EqualError(t, err, "schedule cannot be nil")
+
+	_, err = NewEventTrigger(NewSignal(), nil)
+	assert.Nil(t, err)
+}
+
+func TestEventTrigger_NextExecutionTime(t *testing.T) {
+	trigger, err := NewEventTrigger(NewSignal(), WithEventDebounce(time.Second), WithEventThrottle(time.Minute))
+	assert.Nil(t, err)
+
+	now := time.Now()
+	triggerContext := NewSimpleTriggerContext()
+
+	assert.True(t, trigger.NextExecutionTime(triggerContext).After(now.Add(24*time.Hour)))
+
+	trigger.lastEventTime = now
+	assert.Equal(t, now.Add(time.Second), trigger.NextExecutionTime(triggerContext))
+
+	triggerContext.Update(now.Add(-time.Second), now.Add(-2*time.Second), now.Add(-2*time.Second))
+	assert.Equal(t, now.Add(58*time.Second), trigger.NextExecutionTime(triggerContext))
+
+	triggerContext.Update(now.Add(2*time.Second), now.Add(time.Second), now.Add(time.Second))
+	assert.True(t, trigger.NextExecutionTime(triggerContext).After(now.Add(24*time.Hour)))
+}
+
+func TestScheduleWithTrigger_ChannelEvents(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	events := make(chan string)
+	trigger, err := NewEventTrigger(ChannelEvents(events))
+	assert.Nil(t, err)
+
+	executionTimes := &executionTimes{}
+	task, err := scheduler.ScheduleWithTrigger(executionTimes.task, trigger, WithName("on-event"))
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	assert.Empty(t, executionTimes.get())
+
+	for i := 0; i < 3; i++ {
+		sentTime := time.Now()
+		events <- "created"
+		<-time.After(100 * time.Millisecond)
+
+		times := executionTimes.get()
+		assert.Len(t, times, i+1)
+		assert.Less(t, times[i].Sub(sentTime), 50*time.Millisecond)
+	}
+
+	history := task.(HistoryProvider).History()
+	assert.Len(t, history, 3)
+	assert.Equal(t, ExecutionSucceeded, history[0].Outcome)
+
+	scheduledTask, ok := scheduler.GetTask("on-event")
+	assert.True(t, ok)
+	assert.Equal(t, task, scheduledTask)
+}
+
+func TestScheduleWithTrigger_Signal(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	signal := NewSignal()
+	var counts [2]int32
+
+	for index := range counts {
+		count := &counts[index]
+		trigger, err := NewEventTrigger(signal)
+		assert.Nil(t, err)
+
+		_, err = scheduler.ScheduleWithTrigger(func(ctx context.Context) {
+			atomic.AddInt32(count, 1)
+		}, trigger)
+		assert.Nil(t, err)
+	}
+
+	<-time.After(50 * time.Millisecond)
+	signal.Fire()
+	<-time.After(100 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counts[0]))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counts[1]))
+}
+
+func TestScheduleWithTrigger_FileEvents(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	path := filepath.Join(t.TempDir(), "ready")
+	trigger, err := NewEventTrigger(FileEvents(path, 20*time.Millisecond))
+	assert.Nil(t, err)
+
+	executionTimes := &executionTimes{}
+	_, err = scheduler.ScheduleWithTrigger(executionTimes.task, trigger)
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	assert.Empty(t, executionTimes.get())
+
+	assert.Nil(t, os.WriteFile(path, nil, 0644))
+	<-time.After(100 * time.Millisecond)
+	assert.Len(t, executionTimes.get(), 1)
+
+	<-time.After(100 * time.Millisecond)
+	assert.Len(t, executionTimes.get(), 1)
+
+	assert.Nil(t, os.Remove(path))
+	<-time.After(100 * time.Millisecond)
+	assert.Nil(t, os.WriteFile(path, nil, 0644))
+	<-time.After(100 * time.Millisecond)
+	assert.Len(t, executionTimes.get(), 2)
+}
+
+func TestScheduleWithTrigger_Debounce(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	signal := NewSignal()
+	trigger, err := NewEventTrigger(signal, WithEventDebounce(200*time.Millisecond))
+	assert.Nil(t, err)
+
+	executionTimes := &executionTimes{}
+	_, err = scheduler.ScheduleWithTrigger(executionTimes.task, trigger)
+	assert.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		signal.Fire()
+		<-time.After(100 * time.Millisecond)
+	}
+
+	lastEventTime := time.Now().Add(-100 * time.Millisecond)
+	assert.Empty(t, executionTimes.get())
+
+	<-time.After(200 * time.Millisecond)
+
+	times := executionTimes.get()
+	assert.Len(t, times, 1)
+
+	if len(times) == 1 {
+		assert.GreaterOrEqual(t, times[0].Sub(lastEventTime), 190*time.Millisecond)
+	}
+}
+
+func TestScheduleWithTrigger_Throttle(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	signal := NewSignal()
+	trigger, err := NewEventTrigger(signal, WithEventThrottle(300*time.Millisecond))
+	assert.Nil(t, err)
+
+	executionTimes := &executionTimes{}
+	_, err = scheduler.ScheduleWithTrigger(executionTimes.task, trigger)
+	assert.Nil(t, err)
+
+	<-time.After(50 * time.Millisecond)
+
+	for i := 0; i < 4; i++ {
+		signal.Fire()
+		<-time.After(50 * time.Millisecond)
+	}
+
+	times := executionTimes.get()
+	assert.Len(t, times, 1)
+
+	<-time.After(200 * time.Millisecond)
+
+	times = executionTimes.get()
+	assert.Len(t, times, 2)
+
+	if len(times) == 2 {
+		assert.GreaterOrEqual(t, times[1].Sub(times[0]), 290*time.Millisecond)
+	}
+}
+
+func TestScheduleWithTrigger_EventDuringExecution(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	signal := NewSignal()
+	trigger, err := NewEventTrigger(signal)
+	assert.Nil(t, err)
+
+	var count int32
+
+	_, err = scheduler.ScheduleWithTrigger(func(ctx context.Context) {
+		atomic.AddInt32(&count, 1)
+		<-time.After(100 * time.Millisecond)
+	}, trigger)
+	assert.Nil(t, err)
+
+	<-time.After(50 * time.Millisecond)
+	signal.Fire()
+	<-time.After(50 * time.Millisecond)
+	signal.Fire()
+	signal.Fire()
+	<-time.After(300 * time.Millisecond)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+}
+
+func TestScheduleWithTrigger_EventSchedule(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	cronTrigger, err := CreateCronTrigger("*/2 * * * * *", time.Local)
+	assert.Nil(t, err)
+
+	signal := NewSignal()
+	trigger, err := NewEventTrigger(signal, WithEventSchedule(cronTrigger))
+	assert.Nil(t, err)
+
+	// the task is scheduled right after an even second, so that the cron trigger fires once in the first two seconds
+	now := time.Now()
+	<-time.After(now.Truncate(2 * time.Second).Add(2*time.Second + 100*time.Millisecond).Sub(now))
+
+	executionTimes := &executionTimes{}
+	_, err = scheduler.ScheduleWithTrigger(executionTimes.task, trigger)
+	assert.Nil(t, err)
+
+	<-time.After(2100 * time.Millisecond)
+	assert.Len(t, executionTimes.get(), 1)
+
+	signal.Fire()
+	<-time.After(50 * time.Millisecond)
+	assert.Len(t, executionTimes.get(), 2)
+
+	<-time.After(2 * time.Second)
+	assert.Len(t, executionTimes.get(), 3)
+}
+
+func TestScheduleWithTrigger_Cancel(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	signal := NewSignal()
+	trigger, err := NewEventTrigger(signal)
+	assert.Nil(t, err)
+
+	var count int32
+
+	task, err := scheduler.ScheduleWithTrigger(func(ctx context.Context) {
+		atomic.AddInt32(&count, 1)
+	}, trigger)
+	assert.Nil(t, err)
+
+	<-time.After(50 * time.Millisecond)
+	task.Cancel()
+	<-time.After(50 * time.Millisecond)
+
+	signal.listenersMu.Lock()
+	assert.Empty(t, signal.listeners)
+	signal.listenersMu.Unlock()
+
+	signal.Fire()
+	<-time.After(50 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&count))
+}
+
+func TestScheduleWithTrigger_InvalidTrigger(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	_, err := scheduler.ScheduleWithTrigger(func(ctx context.Context) {}, nil)
+	assert.EqualError(t, err, "trigger cannot be nil")
+}
@@ -0,0 +1,241 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrExecutionMisfired   = errors.New("execution is skipped because of the misfire policy")
+	ErrConcurrentExecution = errors.New("execution is skipped because another execution of the task is running")
+	ErrExecutionTimeout    = errors.New("execution timed out")
+)
+
+type executionContextKey struct{}
+
+type panicError struct {
+	value interface{}
+}
+
+func (err *panicError) Error() string {
+	return fmt.Sprintf("task panicked : %v", err.value)
+}
+
+func isPanicError(err error) bool {
+	var target *panicError
+	return errors.As(err, &target)
+}
+
+type Execution struct {
+	taskID        int
+	taskName      string
+	scheduledTime time.Time
+	startTime     time.Time
+	attempt       int
+	manual        bool
+	err           error
+	result        interface{}
+	skipReason    error
+	tracer        Tracer
+	spanInfo      SpanInfo
+	span          Span
+	executionMu   sync.RWMutex
+}
+
+func newExecution(task *ScheduledRunnableTask, scheduledTime time.Time) *Execution {
+	return &Execution{
+		taskID:        task.id,
+		taskName:      task.name,
+		scheduledTime: scheduledTime,
+		startTime:     time.Now(),
+		attempt:       1,
+		manual:        task.manual,
+	}
+}
+
+func ExecutionFromContext(ctx context.Context) (*Execution, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	execution, ok := ctx.Value(executionContextKey{}).(*Execution)
+	return execution, ok
+}
+
+func (execution *Execution) TaskID() int {
+	return execution.taskID
+}
+
+func (execution *Execution) TaskName() string {
+	return execution.taskName
+}
+
+func (execution *Execution) ScheduledTime() time.Time {
+	return execution.scheduledTime
+}
+
+func (execution *Execution) StartTime() time.Time {
+	return execution.startTime
+}
+
+// Attempt returns the number of the current attempt, which is increased each time the execution is retried.
+func (execution *Execution) Attempt() int {
+	execution.executionMu.RLock()
+	defer execution.executionMu.RUnlock()
+	return execution.attempt
+}
+
+// Manual reports whether the execution has been triggered manually rather than by the schedule of the task.
+func (execution *Execution) Manual() bool {
+	return execution.manual
+}
+
+// Fail marks the execution as failed. Only the first error is kept.
+func (execution *Execution) Fail(err error) {
+	if err == nil {
+		return
+	}
+
+	execution.executionMu.Lock()
+	defer execution.executionMu.Unlock()
+
+	if execution.err == nil {
+		execution.err = err
+	}
+}
+
+func (execution *Execution) Err() error {
+	execution.executionMu.RLock()
+	defer execution.executionMu.RUnlock()
+	return execution.err
+}
+
+// SetResult sets the result of the execution, which is passed to the listeners and kept in the history.
+func (execution *Execution) SetResult(result interface{}) {
+	execution.executionMu.Lock()
+	defer execution.executionMu.Unlock()
+	execution.result = result
+}
+
+func (execution *Execution) Result() interface{} {
+	execution.executionMu.RLock()
+	defer execution.executionMu.RUnlock()
+	return execution.result
+}
+
+// retry clears the error and the result of the failed attempt and starts the next attempt.
+func (execution *Execution) retry() {
+	execution.executionMu.Lock()
+	defer execution.executionMu.Unlock()
+	execution.err = nil
+	execution.result = nil
+	execution.attempt++
+}
+
+// startRun clears the error of the previous runs covered by the same execution, so that the error of the next
+// catch-up run can be evaluated on its own. It returns the cleared error.
+func (execution *Execution) startRun() error {
+	execution.executionMu.Lock()
+	defer execution.executionMu.Unlock()
+	err := execution.err
+	execution.err = nil
+	return err
+}
+
+// endRun returns the error of the completed run and restores the first error of the execution.
+func (execution *Execution) endRun(previousErr error) error {
+	execution.executionMu.Lock()
+	defer execution.executionMu.Unlock()
+	err := execution.err
+
+	if previousErr != nil {
+		execution.err = previousErr
+	}
+
+	return err
+}
+
+func (execution *Execution) skip(reason error) {
+	execution.executionMu.Lock()
+	defer execution.executionMu.Unlock()
+	execution.skipReason = reason
+}
+
+func (execution *Execution) getSkipReason() error {
+	execution.executionMu.RLock()
+	defer execution.executionMu.RUnlock()
+	return execution.skipReason
+}
+
+// startSpan starts a span for the current attempt of the execution if a tracer is set, and returns
+// the context carrying it.
+func (execution *Execution) startSpan(ctx context.Context) context.Context {
+	if execution.tracer == nil {
+		return ctx
+	}
+
+	info := execution.spanInfo
+	info.Attempt = execution.Attempt()
+
+	ctx, span := execution.tracer.Start(ctx, info)
+
+	execution.executionMu.Lock()
+	execution.span = span
+	execution.executionMu.Unlock()
+
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// endSpan records the error of the current attempt on its span, and ends the span.
+func (execution *Execution) endSpan() {
+	execution.executionMu.Lock()
+	span := execution.span
+	err := execution.err
+	execution.span = nil
+	execution.executionMu.Unlock()
+
+	if span == nil {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	span.End()
+}
+
+func (execution *Execution) run(ctx context.Context, task Task) {
+	execution.Fail(runTask(context.WithValue(ctx, executionContextKey{}, execution), task))
+}
+
+func (execution *Execution) event(duration time.Duration) TaskEvent {
+	return TaskEvent{
+		TaskID:        execution.taskID,
+		TaskName:      execution.taskName,
+		ScheduledTime: execution.scheduledTime,
+		StartTime:     execution.startTime,
+		Duration:      duration,
+		Err:           execution.Err(),
+		Result:        execution.Result(),
+		Attempt:       execution.Attempt(),
+		Manual:        execution.manual,
+	}
+}
+
+// runTask runs the task and recovers from any panic it raises. A panicking
+// task does not crash the process; the panic is reported as a *panicError so
+// that the execution fails and the task keeps its schedule.
+func runTask(ctx context.Context, task Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &panicError{r}
+		}
+	}()
+
+	task(ctx)
+	return nil
+}
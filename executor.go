@@ -3,6 +3,7 @@ package chrono
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"sync"
 	"time"
 )
@@ -15,17 +16,66 @@ type TaskExecutor interface {
 	Shutdown() chan bool
 }
 
+type optionScheduler interface {
+	schedule(task Task, delay time.Duration, period time.Duration, fixedRate bool, options ...Option) (ScheduledTask, error)
+}
+
+// scheduleTask schedules the task on the executor with the given options. The options are ignored
+// if the executor does not support them.
+func scheduleTask(executor TaskExecutor, task Task, delay time.Duration, period time.Duration, fixedRate bool, options ...Option) (ScheduledTask, error) {
+	if scheduler, ok := executor.(optionScheduler); ok {
+		return scheduler.schedule(task, delay, period, fixedRate, options...)
+	}
+
+	switch {
+	case period == 0:
+		return executor.Schedule(task, delay)
+	case fixedRate:
+		return executor.ScheduleAtFixedRate(task, delay, period)
+	}
+
+	return executor.ScheduleWithFixedDelay(task, delay, period)
+}
+
 type SimpleTaskExecutor struct {
 	nextSequence          int
 	isShutdown            bool
+	isPaused              bool
+	isStandby             bool
 	executorMu            sync.RWMutex
 	timer                 *time.Timer
 	taskWaitGroup         sync.WaitGroup
 	taskQueue             ScheduledTaskQueue
+	dispatchPaused        bool
 	newTaskChannel        chan *ScheduledRunnableTask
 	rescheduleTaskChannel chan *ScheduledRunnableTask
+	updateTaskChannel     chan *taskUpdate
+	resumeTaskChannel     chan *ScheduledRunnableTask
+	pauseChannel          chan bool
+	standbyChannel        chan standbyChange
 	taskRunner            TaskRunner
 	shutdownChannel       chan chan bool
+	eventDispatcher       *taskEventDispatcher
+	loggerHolder          loggerHolder
+	metricsHolder         metricsHolder
+	tracerHolder          tracerHolder
+	lockerHolder          lockerHolder
+	snapshotChannel       chan chan ExecutorSnapshot
+	runningExecutions     map[*Execution]*ScheduledRunnableTask
+	runningExecutionsMu   sync.Mutex
+}
+
+// standbyChange is sent to the executor once it enters or leaves standby, while it is paused or not.
+type standbyChange struct {
+	standby bool
+	paused  bool
+}
+
+type taskUpdate struct {
+	task        *ScheduledRunnableTask
+	triggerTime time.Time
+	period      time.Duration
+	result      chan error
 }
 
 func NewDefaultTaskExecutor() TaskExecutor {
@@ -42,10 +92,17 @@ func NewSimpleTaskExecutor(runner TaskRunner) *SimpleTaskExecutor {
 		taskQueue:             make(ScheduledTaskQueue, 0),
 		newTaskChannel:        make(chan *ScheduledRunnableTask),
 		rescheduleTaskChannel: make(chan *ScheduledRunnableTask),
+		updateTaskChannel:     make(chan *taskUpdate),
+		resumeTaskChannel:     make(chan *ScheduledRunnableTask),
+		pauseChannel:          make(chan bool),
+		standbyChannel:        make(chan standbyChange),
 		taskRunner:            runner,
 		shutdownChannel:       make(chan chan bool),
+		snapshotChannel:       make(chan chan ExecutorSnapshot),
+		runningExecutions:     make(map[*Execution]*ScheduledRunnableTask),
 	}
 
+	executor.eventDispatcher = newTaskEventDispatcher(&executor.loggerHolder)
 	executor.timer.Stop()
 
 	go executor.run()
@@ -54,122 +111,321 @@ func NewSimpleTaskExecutor(runner TaskRunner) *SimpleTaskExecutor {
 }
 
 func (executor *SimpleTaskExecutor) Schedule(task Task, delay time.Duration) (ScheduledTask, error) {
-	if task == nil {
-		return nil, errors.New("task cannot be nil")
-	}
+	return executor.schedule(task, delay, 0, false)
+}
 
-	executor.executorMu.Lock()
+func (executor *SimpleTaskExecutor) ScheduleWithFixedDelay(task Task, initialDelay time.Duration, delay time.Duration) (ScheduledTask, error) {
+	return executor.schedule(task, initialDelay, delay, false)
+}
 
-	if executor.isShutdown {
-		executor.executorMu.Unlock()
-		return nil, errors.New("no new task won't be accepted because executor is already shut down")
-	}
+func (executor *SimpleTaskExecutor) ScheduleAtFixedRate(task Task, initialDelay time.Duration, period time.Duration) (ScheduledTask, error) {
+	return executor.schedule(task, initialDelay, period, true)
+}
 
-	executor.nextSequence++
-	scheduledTask, err := CreateScheduledRunnableTask(executor.nextSequence, task, executor.calculateTriggerTime(delay), 0, false)
-	executor.executorMu.Unlock()
+func (executor *SimpleTaskExecutor) Pause() {
+	executor.setPaused(true)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (executor *SimpleTaskExecutor) Resume() {
+	executor.setPaused(false)
+}
 
-	executor.addNewTask(scheduledTask)
+func (executor *SimpleTaskExecutor) IsPaused() bool {
+	executor.executorMu.RLock()
+	defer executor.executorMu.RUnlock()
+	return executor.isPaused
+}
 
-	return scheduledTask, nil
+func (executor *SimpleTaskExecutor) AddListener(listener TaskListener) {
+	executor.eventDispatcher.addListener(listener)
 }
 
-func (executor *SimpleTaskExecutor) ScheduleWithFixedDelay(task Task, initialDelay time.Duration, delay time.Duration) (ScheduledTask, error) {
-	if task == nil {
-		return nil, errors.New("task cannot be nil")
+// AddHistorySink adds a sink receiving the record of each execution.
+func (executor *SimpleTaskExecutor) AddHistorySink(sink HistorySink) {
+	if sink != nil {
+		executor.eventDispatcher.addListener(historySinkListener{sink: sink})
 	}
+}
+
+// SetLogger sets the logger used by the executor and the trigger tasks scheduled on it.
+// Nothing is logged by default.
+func (executor *SimpleTaskExecutor) SetLogger(logger *slog.Logger) {
+	executor.loggerHolder.set(logger)
+}
+
+func (executor *SimpleTaskExecutor) logger() *slog.Logger {
+	return executor.loggerHolder.get()
+}
+
+// SetMetrics sets the metrics backend the executor reports to. No metrics are collected by default.
+func (executor *SimpleTaskExecutor) SetMetrics(metrics Metrics) {
+	executor.metricsHolder.set(metrics)
+}
 
+func (executor *SimpleTaskExecutor) metrics() Metrics {
+	return executor.metricsHolder.get()
+}
+
+// SetLocker sets the locker the named tasks acquire a lock from before each execution,
+// so that only one of the instances sharing the locker runs them. No lock is acquired by default.
+func (executor *SimpleTaskExecutor) SetLocker(locker Locker) {
+	executor.lockerHolder.set(locker)
+}
+
+func (executor *SimpleTaskExecutor) locker() Locker {
+	return executor.lockerHolder.get()
+}
+
+// SetTracer sets the tracer creating a span around each execution. No span is created by default.
+func (executor *SimpleTaskExecutor) SetTracer(tracer Tracer) {
+	executor.tracerHolder.set(tracer)
+}
+
+func (executor *SimpleTaskExecutor) tracer() Tracer {
+	return executor.tracerHolder.get()
+}
+
+func (executor *SimpleTaskExecutor) IsShutdown() bool {
 	executor.executorMu.Lock()
+	defer executor.executorMu.Unlock()
+	return executor.isShutdown
+}
+
+func (executor *SimpleTaskExecutor) Shutdown() chan bool {
+	executor.executorMu.Lock()
+	defer executor.executorMu.Unlock()
 
 	if executor.isShutdown {
-		executor.executorMu.Unlock()
-		return nil, errors.New("no new task won't be accepted because executor is already shut down")
+		panic("executor is already shut down")
 	}
 
-	executor.nextSequence++
-	scheduledTask, err := CreateScheduledRunnableTask(executor.nextSequence, task, executor.calculateTriggerTime(initialDelay), delay, false)
-	executor.executorMu.Unlock()
+	executor.isShutdown = true
 
-	if err != nil {
-		return nil, err
-	}
+	stoppedChan := make(chan bool)
+	executor.shutdownChannel <- stoppedChan
+	return stoppedChan
+}
 
-	executor.addNewTask(scheduledTask)
+func (executor *SimpleTaskExecutor) calculateTriggerTime(delay time.Duration) time.Time {
+	if delay < 0 {
+		delay = 0
+	}
 
-	return scheduledTask, nil
+	return time.Now().Add(delay)
 }
 
-func (executor *SimpleTaskExecutor) ScheduleAtFixedRate(task Task, initialDelay time.Duration, period time.Duration) (ScheduledTask, error) {
-	if task == nil {
-		return nil, errors.New("task cannot be nil")
+func (executor *SimpleTaskExecutor) schedule(task Task, delay time.Duration, period time.Duration, fixedRate bool, options ...Option) (ScheduledTask, error) {
+	schedulerTask, err := CreateSchedulerTask(task, options...)
+
+	if err != nil {
+		return nil, err
 	}
 
 	executor.executorMu.Lock()
 
 	if executor.isShutdown {
 		executor.executorMu.Unlock()
+		executor.logger().Warn("task rejected because executor is already shut down",
+			taskLogAttrs(schedulerTask.id, schedulerTask.name)...)
 		return nil, errors.New("no new task won't be accepted because executor is already shut down")
 	}
 
-	executor.nextSequence++
-	scheduledTask, err := CreateScheduledRunnableTask(executor.nextSequence, task, executor.calculateTriggerTime(initialDelay), period, true)
+	id := schedulerTask.id
+
+	if id == 0 {
+		executor.nextSequence++
+		id = executor.nextSequence
+	}
+
+	scheduledTask, err := CreateScheduledRunnableTask(id, task, executor.calculateTriggerTime(delay), period, fixedRate)
 	executor.executorMu.Unlock()
 
 	if err != nil {
 		return nil, err
 	}
 
+	scheduledTask.name = schedulerTask.name
+	scheduledTask.misfirePolicy = schedulerTask.misfirePolicy
+	scheduledTask.paused = schedulerTask.paused
+	scheduledTask.trigger = schedulerTask.trigger
+	scheduledTask.concurrencyPolicy = schedulerTask.concurrencyPolicy
+	scheduledTask.manual = schedulerTask.manual
+	scheduledTask.lockTTL = schedulerTask.lockTTL
+	scheduledTask.lockAtLeast = schedulerTask.lockAtLeast
+	scheduledTask.lockAtLeastSet = schedulerTask.lockAtLeastSet
+
+	if schedulerTask.state != nil {
+		scheduledTask.state = schedulerTask.state
+	}
+
+	if schedulerTask.history != nil {
+		scheduledTask.history = schedulerTask.history
+	} else {
+		scheduledTask.history = newExecutionHistory(schedulerTask.historySize)
+	}
+
+	// the tasks continuing an existing task such as the executions of trigger tasks are not announced
+	if schedulerTask.id == 0 || schedulerTask.replaced {
+		executor.publishEvent(taskEventSchedule, scheduledTask.event(scheduledTask.triggerTime))
+	}
+
+	executor.logger().Debug("task scheduled", taskLogAttrs(scheduledTask.id, scheduledTask.name,
+		slog.Time("trigger_time", scheduledTask.triggerTime),
+		slog.Duration("period", period),
+		slog.Bool("fixed_rate", fixedRate))...)
+
 	executor.addNewTask(scheduledTask)
 
 	return scheduledTask, nil
 }
 
-func (executor *SimpleTaskExecutor) IsShutdown() bool {
+func (executor *SimpleTaskExecutor) addNewTask(task *ScheduledRunnableTask) {
+	task.executor = executor
+	executor.newTaskChannel <- task
+}
+
+func (executor *SimpleTaskExecutor) setPaused(paused bool) {
 	executor.executorMu.Lock()
 	defer executor.executorMu.Unlock()
-	return executor.isShutdown
+
+	if executor.isShutdown || executor.isPaused == paused {
+		return
+	}
+
+	executor.isPaused = paused
+
+	if paused {
+		executor.logger().Info("executor paused")
+	} else {
+		executor.logger().Info("executor resumed")
+	}
+
+	executor.pauseChannel <- executor.isPaused || executor.isStandby
 }
 
-func (executor *SimpleTaskExecutor) Shutdown() chan bool {
+// setStandby stops or starts dispatching the tasks independently of Pause and Resume,
+// which is used by the leader election.
+func (executor *SimpleTaskExecutor) setStandby(standby bool) {
 	executor.executorMu.Lock()
 	defer executor.executorMu.Unlock()
 
+	if executor.isShutdown || executor.isStandby == standby {
+		return
+	}
+
+	executor.isStandby = standby
+
+	if standby {
+		executor.logger().Info("executor is in standby")
+	} else {
+		executor.logger().Info("executor is active")
+	}
+
+	executor.standbyChannel <- standbyChange{standby: executor.isStandby, paused: executor.isPaused}
+}
+
+func (executor *SimpleTaskExecutor) IsStandby() bool {
+	executor.executorMu.RLock()
+	defer executor.executorMu.RUnlock()
+	return executor.isStandby
+}
+
+// requeueTask puts the periodic task back into the queue, and returns false if the executor is
+// already shut down. It must not block in that case, since the executor waits for the running tasks
+// while shutting down.
+func (executor *SimpleTaskExecutor) requeueTask(task *ScheduledRunnableTask) bool {
+	executor.executorMu.RLock()
+	defer executor.executorMu.RUnlock()
+
 	if executor.isShutdown {
-		panic("executor is already shut down")
+		return false
 	}
 
-	executor.isShutdown = true
+	executor.rescheduleTaskChannel <- task
+	return true
+}
 
-	stoppedChan := make(chan bool)
-	executor.shutdownChannel <- stoppedChan
-	return stoppedChan
+func (executor *SimpleTaskExecutor) resumeTask(task *ScheduledRunnableTask) {
+	executor.executorMu.RLock()
+	defer executor.executorMu.RUnlock()
+
+	if !executor.isShutdown {
+		executor.resumeTaskChannel <- task
+	}
 }
 
-func (executor *SimpleTaskExecutor) calculateTriggerTime(delay time.Duration) time.Time {
-	if delay < 0 {
-		delay = 0
+func (executor *SimpleTaskExecutor) rescheduleTask(task *ScheduledRunnableTask, delay time.Duration, period time.Duration) error {
+	executor.executorMu.RLock()
+	defer executor.executorMu.RUnlock()
+
+	if executor.isShutdown {
+		return errors.New("task cannot be rescheduled because executor is already shut down")
 	}
 
-	return time.Now().Add(delay)
+	update := &taskUpdate{
+		task:        task,
+		triggerTime: executor.calculateTriggerTime(delay),
+		period:      period,
+		result:      make(chan error, 1),
+	}
+
+	executor.updateTaskChannel <- update
+	return <-update.result
 }
 
-func (executor *SimpleTaskExecutor) addNewTask(task *ScheduledRunnableTask) {
-	executor.newTaskChannel <- task
+func (executor *SimpleTaskExecutor) updateTask(update *taskUpdate) error {
+	queued := false
+
+	for _, scheduledTask := range executor.taskQueue {
+		if scheduledTask == update.task {
+			queued = true
+			break
+		}
+	}
+
+	task := update.task
+	task.taskMu.Lock()
+	defer task.taskMu.Unlock()
+
+	if task.cancelled {
+		return errors.New("task cannot be rescheduled because it is already cancelled")
+	}
+
+	if !queued && task.period == 0 {
+		return errors.New("task cannot be rescheduled because it has already been started")
+	}
+
+	task.period = update.period
+	executor.logger().Debug("task rescheduled", taskLogAttrs(task.id, task.name,
+		slog.Time("trigger_time", update.triggerTime),
+		slog.Duration("period", update.period))...)
+	executor.publishEvent(taskEventReschedule, TaskEvent{
+		TaskID:        task.id,
+		TaskName:      task.name,
+		ScheduledTime: update.triggerTime,
+	})
+
+	// a fixed-rate task which is not in the queue is on its way back to the queue,
+	// whereas a fixed-delay task will be put back into the queue after its execution
+	if queued || task.fixedRate {
+		task.triggerTime = update.triggerTime
+	} else {
+		task.pendingTriggerTime = update.triggerTime
+	}
+
+	return nil
 }
 
 func (executor *SimpleTaskExecutor) run() {
 
 	for {
 		executor.taskQueue.SorByTriggerTime()
+		executor.metrics().SetQueueLength(len(executor.taskQueue))
 
-		if len(executor.taskQueue) == 0 {
+		if nextTask := executor.nextTask(); nextTask == nil {
 			executor.timer.Stop()
 		} else {
-			executor.timer.Reset(executor.taskQueue[0].getDelay())
+			executor.timer.Reset(nextTask.getDelay())
 		}
 
 		for {
@@ -177,36 +433,67 @@ func (executor *SimpleTaskExecutor) run() {
 			case clock := <-executor.timer.C:
 				executor.timer.Stop()
 
-				taskIndex := -1
-				for index, scheduledTask := range executor.taskQueue {
+				taskQueue := make(ScheduledTaskQueue, 0, len(executor.taskQueue))
+				for _, scheduledTask := range executor.taskQueue {
 
-					if scheduledTask.triggerTime.After(clock) || scheduledTask.triggerTime.IsZero() {
-						break
+					if scheduledTask.IsCancelled() {
+						continue
 					}
 
-					taskIndex = index
-
-					if scheduledTask.IsCancelled() {
+					if scheduledTask.triggerTime.After(clock) || scheduledTask.triggerTime.IsZero() ||
+						executor.dispatchPaused || scheduledTask.IsPaused() {
+						taskQueue = append(taskQueue, scheduledTask)
 						continue
 					}
 
+					scheduledTime := scheduledTask.triggerTime
+
 					if scheduledTask.isPeriodic() && scheduledTask.isFixedRate() {
 						scheduledTask.triggerTime = scheduledTask.triggerTime.Add(scheduledTask.period)
 					}
 
-					executor.startTask(scheduledTask)
+					executor.startTask(scheduledTask, scheduledTime)
 				}
 
-				executor.taskQueue = executor.taskQueue[taskIndex+1:]
+				executor.taskQueue = taskQueue
 			case newScheduledTask := <-executor.newTaskChannel:
 				executor.timer.Stop()
 				executor.taskQueue = append(executor.taskQueue, newScheduledTask)
 			case rescheduledTask := <-executor.rescheduleTaskChannel:
 				executor.timer.Stop()
+				rescheduledTask.applyPendingTriggerTime()
 				executor.taskQueue = append(executor.taskQueue, rescheduledTask)
+			case update := <-executor.updateTaskChannel:
+				executor.timer.Stop()
+				update.result <- executor.updateTask(update)
+			case resumedTask := <-executor.resumeTaskChannel:
+				executor.timer.Stop()
+
+				if !executor.dispatchPaused {
+					executor.handleMisfires(resumedTask, false)
+				}
+			case paused := <-executor.pauseChannel:
+				executor.timer.Stop()
+				executor.dispatchPaused = paused
+
+				if !paused {
+					executor.handleMisfires(nil, false)
+				}
+			case change := <-executor.standbyChannel:
+				executor.timer.Stop()
+				executor.dispatchPaused = change.standby || change.paused
+
+				// the executions missed in standby are the ones of the active instance, so they are skipped
+				if !change.standby {
+					executor.handleMisfires(nil, true)
+				}
+			case snapshotChan := <-executor.snapshotChannel:
+				snapshotChan <- executor.snapshot()
 			case stoppedChan := <-executor.shutdownChannel:
 				executor.timer.Stop()
+				executor.logger().Info("executor shutting down", slog.Int("queued_tasks", len(executor.taskQueue)))
 				executor.taskWaitGroup.Wait()
+				executor.eventDispatcher.close()
 				stoppedChan <- true
 				return
 			}
@@ -218,13 +505,58 @@ func (executor *SimpleTaskExecutor) run() {
 
 }
 
-func (executor *SimpleTaskExecutor) startTask(scheduledRunnableTask *ScheduledRunnableTask) {
+func (executor *SimpleTaskExecutor) nextTask() *ScheduledRunnableTask {
+	if executor.dispatchPaused {
+		return nil
+	}
+
+	for _, scheduledTask := range executor.taskQueue {
+		if !scheduledTask.IsPaused() {
+			return scheduledTask
+		}
+	}
+
+	return nil
+}
+
+// handleMisfires applies the misfire policies of the queued tasks whose trigger time has passed
+// while they were paused, or skips their missed executions if skip is true. If the given task is nil,
+// the policies of all queued tasks are applied.
+func (executor *SimpleTaskExecutor) handleMisfires(resumedTask *ScheduledRunnableTask, skip bool) {
+	now := time.Now()
+
+	taskQueue := make(ScheduledTaskQueue, 0, len(executor.taskQueue))
+	for _, scheduledTask := range executor.taskQueue {
+
+		if (resumedTask != nil && scheduledTask != resumedTask) || scheduledTask.IsPaused() ||
+			!scheduledTask.triggerTime.Before(now) || scheduledTask.triggerTime.IsZero() {
+			taskQueue = append(taskQueue, scheduledTask)
+			continue
+		}
+
+		scheduledTime := scheduledTask.triggerTime
+		executor.metrics().IncTaskMisfires(scheduledTask.name)
+		keep := scheduledTask.misfire(now, skip)
+
+		if keep {
+			taskQueue = append(taskQueue, scheduledTask)
+		}
+
+		if !keep || !scheduledTask.triggerTime.Equal(scheduledTime) {
+			executor.skipTask(scheduledTask, scheduledTime, ErrExecutionMisfired, slog.Bool("dropped", !keep))
+		}
+	}
+
+	executor.taskQueue = taskQueue
+}
+
+func (executor *SimpleTaskExecutor) startTask(scheduledRunnableTask *ScheduledRunnableTask, scheduledTime time.Time) {
 	executor.taskWaitGroup.Add(1)
 
 	executor.taskRunner.Run(func(ctx context.Context) {
 		defer func() {
 			if executor.IsShutdown() {
-				scheduledRunnableTask.Cancel()
+				scheduledRunnableTask.cancel()
 				executor.taskWaitGroup.Done()
 				return
 			}
@@ -232,20 +564,107 @@ func (executor *SimpleTaskExecutor) startTask(scheduledRunnableTask *ScheduledRu
 			executor.taskWaitGroup.Done()
 
 			if !scheduledRunnableTask.isPeriodic() {
-				scheduledRunnableTask.Cancel()
+				scheduledRunnableTask.cancel()
 			} else {
 				if !scheduledRunnableTask.isFixedRate() {
-					scheduledRunnableTask.triggerTime = executor.calculateTriggerTime(scheduledRunnableTask.period)
-					executor.rescheduleTaskChannel <- scheduledRunnableTask
+					scheduledRunnableTask.triggerTime = executor.calculateTriggerTime(scheduledRunnableTask.getPeriod())
+					executor.requeueTask(scheduledRunnableTask)
 				}
 			}
 		}()
 
 		if scheduledRunnableTask.isPeriodic() && scheduledRunnableTask.isFixedRate() {
-			executor.rescheduleTaskChannel <- scheduledRunnableTask
+			if !executor.requeueTask(scheduledRunnableTask) {
+				return
+			}
+		} else if executor.IsShutdown() {
+			return
+		}
+
+		execution := newExecution(scheduledRunnableTask, scheduledTime)
+
+		if !scheduledRunnableTask.state.tryStart(scheduledRunnableTask.concurrencyPolicy) {
+			executor.skipTask(scheduledRunnableTask, scheduledTime, ErrConcurrentExecution)
+			return
+		}
+
+		defer scheduledRunnableTask.state.done()
+
+		// the executions of trigger tasks acquire their locks by themselves to keep being scheduled
+		if scheduledRunnableTask.trigger == nil {
+			release, err := acquireTaskLock(ctx, executor, scheduledRunnableTask.id, scheduledRunnableTask.name,
+				scheduledRunnableTask.lockTTL, lockHoldDuration(scheduledRunnableTask.lockAtLeast,
+					scheduledRunnableTask.lockAtLeastSet, scheduledRunnableTask.getPeriod()))
+
+			if err != nil {
+				executor.skipTask(scheduledRunnableTask, scheduledTime, err)
+				return
+			}
+
+			defer release()
+		}
+
+		executor.addRunningExecution(execution, scheduledRunnableTask)
+		defer executor.removeRunningExecution(execution)
+
+		metrics := executor.metrics()
+		metrics.AddRunningTasks(scheduledRunnableTask.name, 1)
+		executor.publishEvent(taskEventBeforeRun, scheduledRunnableTask.event(scheduledTime))
+		executor.logger().Debug("task started", taskLogAttrs(scheduledRunnableTask.id, scheduledRunnableTask.name,
+			slog.Time("scheduled_time", scheduledTime))...)
+
+		execution.tracer = executor.tracer()
+		execution.spanInfo = scheduledRunnableTask.spanInfo(execution)
+		execution.run(execution.startSpan(ctx), scheduledRunnableTask.task)
+		execution.endSpan()
+
+		metrics.AddRunningTasks(scheduledRunnableTask.name, -1)
+		event := execution.event(time.Since(execution.StartTime()))
+
+		if skipReason := execution.getSkipReason(); skipReason != nil {
+			executor.skipTask(scheduledRunnableTask, scheduledTime, skipReason)
+			return
 		}
 
-		scheduledRunnableTask.task(ctx)
+		metrics.IncTaskRuns(scheduledRunnableTask.name)
+		metrics.ObserveTaskStartLateness(scheduledRunnableTask.name, execution.StartTime().Sub(scheduledTime))
+		metrics.ObserveTaskDuration(scheduledRunnableTask.name, event.Duration)
+
+		if event.Err != nil {
+			scheduledRunnableTask.history.add(newExecutionRecord(event, ExecutionFailed))
+			metrics.IncTaskFailures(scheduledRunnableTask.name)
+
+			if isPanicError(event.Err) {
+				metrics.IncTaskPanics(scheduledRunnableTask.name)
+			}
+
+			executor.logger().Error("task failed", taskLogAttrs(scheduledRunnableTask.id, scheduledRunnableTask.name,
+				slog.Duration("duration", event.Duration),
+				slog.Any("error", event.Err))...)
+			executor.publishEvent(taskEventError, event)
+		} else {
+			scheduledRunnableTask.history.add(newExecutionRecord(event, ExecutionSucceeded))
+			executor.logger().Debug("task completed", taskLogAttrs(scheduledRunnableTask.id, scheduledRunnableTask.name,
+				slog.Duration("duration", event.Duration))...)
+		}
+
+		executor.publishEvent(taskEventAfterRun, event)
 	})
 
 }
+
+// skipTask reports that an execution of the task is skipped with the given reason.
+func (executor *SimpleTaskExecutor) skipTask(task *ScheduledRunnableTask, scheduledTime time.Time, reason error, attrs ...any) {
+	executor.metrics().IncTaskSkips(task.name)
+	executor.logger().Warn("task execution skipped", taskLogAttrs(task.id, task.name,
+		append([]any{slog.Time("scheduled_time", scheduledTime), slog.Any("reason", reason)}, attrs...)...)...)
+
+	event := task.event(scheduledTime)
+	event.Err = reason
+	task.history.add(newExecutionRecord(event, ExecutionSkipped))
+	executor.publishEvent(taskEventSkip, event)
+}
+
+func (executor *SimpleTaskExecutor) publishEvent(eventType taskEventType, event TaskEvent) {
+	executor.eventDispatcher.publish(eventType, event)
+}
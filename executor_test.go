@@ -130,6 +130,31 @@ func TestSimpleTaskExecutor_ScheduleAtFixedRateWithInitialDelay(t *testing.T) {
 		"number of scheduled task execution must be between 5 and 10, actual: %d", counter)
 }
 
+func TestSimpleTaskExecutor_ScheduleAtFixedRate_PanickingTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+
+	task, err := executor.ScheduleAtFixedRate(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+		panic("test panic")
+	}, 0, 200*time.Millisecond)
+
+	assert.Nil(t, err)
+
+	<-time.After(1*time.Second - 50*time.Millisecond)
+	task.Cancel()
+	assert.True(t, atomic.LoadInt32(&counter) >= 3,
+		"panicking task must keep being executed, actual: %d", counter)
+
+	history := task.(HistoryProvider).History()
+	assert.NotEmpty(t, history)
+	for _, record := range history {
+		assert.True(t, isPanicError(record.Err))
+		assert.Equal(t, "task panicked : test panic", record.Err.Error())
+	}
+}
+
 func TestSimpleTaskExecutor_Shutdown(t *testing.T) {
 	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
 
@@ -241,3 +266,250 @@ func TestSimpleTaskExecutor_Shutdown_TerminatedExecutor(t *testing.T) {
 		executor.Shutdown()
 	})
 }
+
+func TestSimpleTaskExecutor_Reschedule_OneShotTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+
+	task, err := executor.Schedule(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 5*time.Second)
+
+	assert.Nil(t, err)
+
+	err = task.(*ScheduledRunnableTask).Reschedule(500*time.Millisecond, 0)
+	assert.Nil(t, err)
+
+	<-time.After(1 * time.Second)
+	assert.True(t, task.IsCancelled(), "scheduled task must have been cancelled")
+	assert.True(t, counter == 1,
+		"number of scheduled task execution must be 1, actual: %d", counter)
+
+	err = task.(*ScheduledRunnableTask).Reschedule(500*time.Millisecond, 0)
+	assert.Error(t, err)
+}
+
+func TestSimpleTaskExecutor_Reschedule_FixedRateTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+
+	task, err := executor.ScheduleAtFixedRate(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 0, 1*time.Second)
+
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	err = task.(*ScheduledRunnableTask).Reschedule(0, 100*time.Millisecond)
+	assert.Nil(t, err)
+
+	<-time.After(1*time.Second - 50*time.Millisecond)
+	task.Cancel()
+	assert.True(t, counter >= 5,
+		"number of scheduled task execution must be at least 5, actual: %d", counter)
+}
+
+func TestSimpleTaskExecutor_Reschedule_RunningFixedDelayTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+
+	task, err := executor.ScheduleWithFixedDelay(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+		<-time.After(300 * time.Millisecond)
+	}, 0, 5*time.Second)
+
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	err = task.(*ScheduledRunnableTask).Reschedule(500*time.Millisecond, 5*time.Second)
+	assert.Nil(t, err)
+
+	<-time.After(1 * time.Second)
+	task.Cancel()
+	assert.True(t, counter == 2,
+		"number of scheduled task execution must be 2, actual: %d", counter)
+}
+
+func TestSimpleTaskExecutor_Reschedule_InvalidPeriod(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	oneShotTask, err := executor.Schedule(func(ctx context.Context) {}, 5*time.Second)
+	assert.Nil(t, err)
+	assert.Error(t, oneShotTask.(*ScheduledRunnableTask).Reschedule(time.Second, time.Second))
+
+	periodicTask, err := executor.ScheduleAtFixedRate(func(ctx context.Context) {}, 5*time.Second, time.Second)
+	assert.Nil(t, err)
+	assert.Error(t, periodicTask.(*ScheduledRunnableTask).Reschedule(time.Second, 0))
+
+	oneShotTask.Cancel()
+	periodicTask.Cancel()
+}
+
+func TestSimpleTaskExecutor_PauseAndResumeTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+
+	task, err := executor.ScheduleAtFixedRate(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 200*time.Millisecond, 200*time.Millisecond)
+
+	assert.Nil(t, err)
+
+	task.(Pausable).Pause()
+	assert.True(t, task.(Pausable).IsPaused())
+
+	<-time.After(1 * time.Second)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counter))
+
+	task.(Pausable).Resume()
+	assert.False(t, task.(Pausable).IsPaused())
+
+	<-time.After(500 * time.Millisecond)
+	task.Cancel()
+	assert.True(t, atomic.LoadInt32(&counter) >= 2,
+		"number of scheduled task execution must be at least 2, actual: %d", counter)
+}
+
+func TestSimpleTaskExecutor_PauseAndResume(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+
+	executor.Pause()
+	assert.True(t, executor.IsPaused())
+
+	task, err := executor.Schedule(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 200*time.Millisecond)
+
+	assert.Nil(t, err)
+
+	<-time.After(500 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counter))
+
+	executor.Resume()
+	assert.False(t, executor.IsPaused())
+
+	<-time.After(200 * time.Millisecond)
+	assert.True(t, task.IsCancelled(), "scheduled task must have been cancelled")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+}
+
+func TestSimpleTaskExecutor_MisfirePolicy(t *testing.T) {
+	testCases := []struct {
+		policy        MisfirePolicy
+		expectedCount int32
+	}{
+		{MisfirePolicyRunOnce, 1},
+		{MisfirePolicyRunAll, 5},
+		{MisfirePolicySkip, 0},
+	}
+
+	for _, testCase := range testCases {
+		executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+		var counter int32
+
+		task, err := executor.schedule(func(ctx context.Context) {
+			atomic.AddInt32(&counter, 1)
+		}, 100*time.Millisecond, 200*time.Millisecond, true, WithMisfirePolicy(testCase.policy))
+
+		assert.Nil(t, err)
+
+		executor.Pause()
+		<-time.After(1 * time.Second)
+		executor.Resume()
+
+		<-time.After(50 * time.Millisecond)
+		task.Cancel()
+		assert.Equal(t, testCase.expectedCount, atomic.LoadInt32(&counter),
+			"number of scheduled task execution for misfire policy %d", testCase.policy)
+	}
+}
+
+func TestSimpleTaskExecutor_MisfirePolicySkip_OneShotTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+
+	task, err := executor.schedule(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 100*time.Millisecond, 0, false, WithMisfirePolicy(MisfirePolicySkip))
+
+	assert.Nil(t, err)
+
+	task.(Pausable).Pause()
+	<-time.After(300 * time.Millisecond)
+	task.(Pausable).Resume()
+
+	<-time.After(100 * time.Millisecond)
+	assert.True(t, task.IsCancelled(), "scheduled task must have been cancelled")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counter))
+}
+
+func TestSimpleTaskExecutor_TriggerNow(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+	manualRuns := make(chan bool, 10)
+
+	task, err := executor.ScheduleAtFixedRate(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+		execution, _ := ExecutionFromContext(ctx)
+		manualRuns <- execution.Manual()
+	}, 1*time.Hour, 1*time.Hour)
+	assert.Nil(t, err)
+
+	assert.Nil(t, task.(Triggerable).TriggerNow())
+	assert.Nil(t, task.(Triggerable).TriggerNow())
+
+	<-time.After(100 * time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&counter))
+	assert.True(t, <-manualRuns)
+	assert.True(t, <-manualRuns)
+
+	snapshot := executor.Snapshot()
+	assert.Len(t, snapshot.QueuedTasks, 1)
+	assert.True(t, snapshot.QueuedTasks[0].NextTriggerTime.After(time.Now().Add(59*time.Minute)))
+
+	task.Cancel()
+	assert.Error(t, task.(Triggerable).TriggerNow())
+}
+
+func TestSimpleTaskExecutor_ConcurrencyPolicyForbid(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	listener := newRecordingTaskListener()
+	executor.AddListener(listener)
+
+	var counter int32
+
+	task, err := executor.schedule(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+		<-time.After(250 * time.Millisecond)
+	}, 0, 100*time.Millisecond, true, WithConcurrencyPolicy(ConcurrencyPolicyForbid))
+	assert.Nil(t, err)
+
+	<-time.After(50 * time.Millisecond)
+	assert.Nil(t, task.(Triggerable).TriggerNow())
+
+	<-time.After(400 * time.Millisecond)
+	task.Cancel()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&counter))
+	<-time.After(50 * time.Millisecond)
+	assert.True(t, len(listener.get("OnSkip")) >= 3, "actual: %d", len(listener.get("OnSkip")))
+
+	for _, event := range listener.get("OnSkip") {
+		assert.Equal(t, ErrConcurrentExecution, event.Err)
+	}
+}
+
+func TestNewSchedulerTask_WithInvalidConcurrencyPolicy(t *testing.T) {
+	_, err := CreateSchedulerTask(func(ctx context.Context) {
+	}, WithConcurrencyPolicy(ConcurrencyPolicy(-1)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package chrono
+
+import (
+	"sync"
+	"time"
+)
+
+const DefaultHistorySize = 10
+
+type ExecutionOutcome int
+
+const (
+	ExecutionSucceeded ExecutionOutcome = iota
+	ExecutionFailed
+	ExecutionSkipped
+)
+
+func (outcome ExecutionOutcome) String() string {
+	switch outcome {
+	case ExecutionSucceeded:
+		return "succeeded"
+	case ExecutionFailed:
+		return "failed"
+	case ExecutionSkipped:
+		return "skipped"
+	}
+
+	return "unknown"
+}
+
+type ExecutionRecord struct {
+	TaskID        int
+	TaskName      string
+	ScheduledTime time.Time
+	StartTime     time.Time
+	EndTime       time.Time
+	Duration      time.Duration
+	Outcome       ExecutionOutcome
+	// Err is the error the execution failed with, or the reason why the execution is skipped.
+	Err     error
+	Result  interface{}
+	Attempt int
+	Manual  bool
+}
+
+func newExecutionRecord(event TaskEvent, outcome ExecutionOutcome) ExecutionRecord {
+	record := ExecutionRecord{
+		TaskID:        event.TaskID,
+		TaskName:      event.TaskName,
+		ScheduledTime: event.ScheduledTime,
+		StartTime:     event.StartTime,
+		Duration:      event.Duration,
+		Outcome:       outcome,
+		Err:           event.Err,
+		Result:        event.Result,
+		Attempt:       event.Attempt,
+		Manual:        event.Manual,
+	}
+
+	if !event.StartTime.IsZero() {
+		record.EndTime = event.StartTime.Add(event.Duration)
+	}
+
+	return record
+}
+
+// HistorySink receives the record of each execution, so that the history of the tasks can be persisted.
+// The records are delivered in a separate goroutine, the same way as the events of the listeners.
+type HistorySink interface {
+	Record(record ExecutionRecord)
+}
+
+type historySinkListener struct {
+	TaskListenerAdapter
+	sink HistorySink
+}
+
+func (listener historySinkListener) AfterRun(event TaskEvent) {
+	if event.Err != nil {
+		listener.sink.Record(newExecutionRecord(event, ExecutionFailed))
+	} else {
+		listener.sink.Record(newExecutionRecord(event, ExecutionSucceeded))
+	}
+}
+
+func (listener historySinkListener) OnSkip(event TaskEvent) {
+	listener.sink.Record(newExecutionRecord(event, ExecutionSkipped))
+}
+
+// executionHistory keeps the last records of the executions of a task in a ring buffer.
+type executionHistory struct {
+	records   []ExecutionRecord
+	next      int
+	full      bool
+	historyMu sync.RWMutex
+}
+
+func newExecutionHistory(size int) *executionHistory {
+	return &executionHistory{
+		records: make([]ExecutionRecord, size),
+	}
+}
+
+func (history *executionHistory) add(record ExecutionRecord) {
+	history.historyMu.Lock()
+	defer history.historyMu.Unlock()
+
+	if len(history.records) == 0 {
+		return
+	}
+
+	history.records[history.next] = record
+	history.next = (history.next + 1) % len(history.records)
+
+	if history.next == 0 {
+		history.full = true
+	}
+}
+
+// list returns the records from the oldest to the newest.
+func (history *executionHistory) list() []ExecutionRecord {
+	history.historyMu.RLock()
+	defer history.historyMu.RUnlock()
+
+	if !history.full {
+		return append([]ExecutionRecord{}, history.records[:history.next]...)
+	}
+
+	records := make([]ExecutionRecord, 0, len(history.records))
+	records = append(records, history.records[history.next:]...)
+	return append(records, history.records[:history.next]...)
+}
@@ -0,0 +1,170 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingHistorySink struct {
+	records   []ExecutionRecord
+	recordsMu sync.Mutex
+}
+
+func (sink *recordingHistorySink) Record(record ExecutionRecord) {
+	sink.recordsMu.Lock()
+	defer sink.recordsMu.Unlock()
+	sink.records = append(sink.records, record)
+}
+
+func (sink *recordingHistorySink) get() []ExecutionRecord {
+	sink.recordsMu.Lock()
+	defer sink.recordsMu.Unlock()
+	return append([]ExecutionRecord{}, sink.records...)
+}
+
+func TestExecutionHistory(t *testing.T) {
+	history := newExecutionHistory(3)
+	assert.Empty(t, history.list())
+
+	for id := 1; id <= 2; id++ {
+		history.add(ExecutionRecord{TaskID: id})
+	}
+
+	assert.Equal(t, []ExecutionRecord{{TaskID: 1}, {TaskID: 2}}, history.list())
+
+	for id := 3; id <= 5; id++ {
+		history.add(ExecutionRecord{TaskID: id})
+	}
+
+	assert.Equal(t, []ExecutionRecord{{TaskID: 3}, {TaskID: 4}, {TaskID: 5}}, history.list())
+}
+
+func TestExecutionHistory_WithoutSize(t *testing.T) {
+	history := newExecutionHistory(0)
+	history.add(ExecutionRecord{TaskID: 1})
+	assert.Empty(t, history.list())
+}
+
+func TestScheduledRunnableTask_History(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	var counter int32
+
+	task, err := executor.schedule(func(ctx context.Context) {
+		execution, _ := ExecutionFromContext(ctx)
+		count := atomic.AddInt32(&counter, 1)
+		execution.SetResult(count)
+
+		if count%2 == 0 {
+			execution.Fail(errors.New("test error"))
+		}
+	}, 0, 1*time.Hour, true, WithName("fixed-rate-task"), WithHistorySize(3))
+	assert.Nil(t, err)
+
+	<-time.After(50 * time.Millisecond)
+	history := task.(HistoryProvider).History()
+	assert.Len(t, history, 1)
+	assert.False(t, history[0].Manual)
+	assert.Equal(t, ExecutionSucceeded, history[0].Outcome)
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, task.(Triggerable).TriggerNow())
+		<-time.After(50 * time.Millisecond)
+	}
+
+	task.Cancel()
+
+	history = task.(HistoryProvider).History()
+	assert.Len(t, history, 3)
+
+	for _, record := range history {
+		assert.Equal(t, "fixed-rate-task", record.TaskName)
+		assert.Equal(t, task.(Identifiable).ID(), record.TaskID)
+		assert.Equal(t, 1, record.Attempt)
+		assert.True(t, record.Manual)
+		assert.False(t, record.StartTime.Before(record.ScheduledTime))
+		assert.Equal(t, record.EndTime, record.StartTime.Add(record.Duration))
+	}
+
+	assert.Equal(t, ExecutionFailed, history[0].Outcome)
+	assert.Equal(t, "test error", history[0].Err.Error())
+	assert.Equal(t, int32(2), history[0].Result)
+	assert.Equal(t, ExecutionSucceeded, history[1].Outcome)
+	assert.Nil(t, history[1].Err)
+	assert.Equal(t, int32(3), history[1].Result)
+	assert.Equal(t, ExecutionFailed, history[2].Outcome)
+}
+
+func TestScheduledRunnableTask_History_Skipped(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	task, err := executor.schedule(func(ctx context.Context) {
+		<-time.After(150 * time.Millisecond)
+	}, 0, 100*time.Millisecond, true, WithConcurrencyPolicy(ConcurrencyPolicyForbid))
+	assert.Nil(t, err)
+
+	<-time.After(120 * time.Millisecond)
+	task.Cancel()
+
+	history := task.(HistoryProvider).History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, ExecutionSkipped, history[0].Outcome)
+	assert.Equal(t, ErrConcurrentExecution, history[0].Err)
+	assert.True(t, history[0].StartTime.IsZero())
+	assert.True(t, history[0].EndTime.IsZero())
+}
+
+func TestTriggerTask_History(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewSimpleTaskExecutor(NewDefaultTaskRunner()))
+	sink := &recordingHistorySink{}
+	scheduler.AddHistorySink(sink)
+
+	_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		panic("test panic")
+	}, "* * * * * *", WithName("cron-task"))
+	assert.Nil(t, err)
+
+	<-time.After(1100 * time.Millisecond)
+	assert.Nil(t, scheduler.TriggerNow("cron-task"))
+	<-time.After(100 * time.Millisecond)
+
+	history, err := scheduler.History("cron-task")
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, len(history), 2)
+
+	assert.Equal(t, ExecutionFailed, history[0].Outcome)
+	assert.Equal(t, "task panicked : test panic", history[0].Err.Error())
+	assert.False(t, history[0].Manual)
+
+	manualRuns := 0
+
+	for _, record := range history {
+		if record.Manual {
+			manualRuns++
+		}
+	}
+
+	assert.Equal(t, 1, manualRuns)
+	assert.Equal(t, history, sink.get())
+
+	_, err = scheduler.History("unknown-task")
+	assert.Error(t, err)
+}
+
+func TestNewSchedulerTask_WithInvalidHistorySize(t *testing.T) {
+	_, err := CreateSchedulerTask(func(ctx context.Context) {
+	}, WithHistorySize(-1))
+	assert.Error(t, err)
+}
+
+func TestExecutionOutcome_String(t *testing.T) {
+	assert.Equal(t, "succeeded", ExecutionSucceeded.String())
+	assert.Equal(t, "failed", ExecutionFailed.String())
+	assert.Equal(t, "skipped", ExecutionSkipped.String())
+	assert.Equal(t, "unknown", ExecutionOutcome(-1).String())
+}
@@ -0,0 +1,223 @@
+package chrono
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// Job is the definition of a task which can be persisted in a job store. The function of the task
+// is referred by the name it is registered with, so that the job can be scheduled again after a restart.
+// A job runs only once unless one of Cron, FixedDelay or FixedRate is given.
+type Job struct {
+	Name              string            `json:"name"`
+	TaskName          string            `json:"task_name"`
+	Cron              string            `json:"cron,omitempty"`
+	FixedDelay        time.Duration     `json:"fixed_delay,omitempty"`
+	FixedRate         time.Duration     `json:"fixed_rate,omitempty"`
+	StartTime         time.Time         `json:"start_time"`
+	Location          string            `json:"location,omitempty"`
+	MisfirePolicy     MisfirePolicy     `json:"misfire_policy,omitempty"`
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrency_policy,omitempty"`
+	Timeout           time.Duration     `json:"timeout,omitempty"`
+	RetryAttempts     int               `json:"retry_attempts,omitempty"`
+	RetryDelay        time.Duration     `json:"retry_delay,omitempty"`
+}
+
+// JobState is the state of the trigger context of a job.
+type JobState struct {
+	LastCompletionTime         time.Time `json:"last_completion_time"`
+	LastExecutionTime          time.Time `json:"last_execution_time"`
+	LastTriggeredExecutionTime time.Time `json:"last_triggered_execution_time"`
+}
+
+type StoredJob struct {
+	Job   Job
+	State JobState
+}
+
+type JobStore interface {
+	SaveJob(job Job) error
+	SaveJobState(name string, state JobState) error
+	DeleteJob(name string) error
+	LoadJobs() ([]StoredJob, error)
+}
+
+func (job Job) validate() error {
+	if job.Name == "" {
+		return errors.New("job name cannot be empty")
+	}
+
+	if job.TaskName == "" {
+		return errors.New("task name of job cannot be empty")
+	}
+
+	triggers := 0
+
+	if job.Cron != "" {
+		triggers++
+	}
+
+	if job.FixedDelay != 0 {
+		triggers++
+	}
+
+	if job.FixedRate != 0 {
+		triggers++
+	}
+
+	if triggers > 1 {
+		return errors.New("only one of cron, fixed delay and fixed rate can be given for a job")
+	}
+
+	if job.FixedDelay < 0 || job.FixedRate < 0 {
+		return errors.New("fixed delay and fixed rate of a job cannot be negative")
+	}
+
+	if job.Timeout < 0 || job.RetryAttempts < 0 || job.RetryDelay < 0 {
+		return errors.New("timeout, retry attempts and retry delay of a job cannot be negative")
+	}
+
+	return nil
+}
+
+func (job Job) options() []Option {
+	options := []Option{
+		WithName(job.Name),
+		WithMisfirePolicy(job.MisfirePolicy),
+		WithConcurrencyPolicy(job.ConcurrencyPolicy),
+	}
+
+	if !job.StartTime.IsZero() {
+		options = append(options, WithTime(job.startTime()))
+	}
+
+	// the location is applied after the start time, since WithTime uses the location of the start time
+	if job.Location != "" {
+		options = append(options, WithLocation(job.Location))
+	}
+
+	if job.Timeout > 0 {
+		options = append(options, WithTimeout(job.Timeout))
+	}
+
+	if job.RetryAttempts > 0 {
+		options = append(options, WithRetry(job.RetryAttempts, job.RetryDelay))
+	}
+
+	return options
+}
+
+// startTime returns the start time of the job in its location, so that it is the same instant
+// whichever location it has been given in.
+func (job Job) startTime() time.Time {
+	if job.Location == "" {
+		return job.StartTime
+	}
+
+	location, err := time.LoadLocation(job.Location)
+
+	if err != nil {
+		return job.StartTime
+	}
+
+	return job.StartTime.In(location)
+}
+
+func (job Job) isOneShot() bool {
+	return job.Cron == "" && job.FixedDelay == 0 && job.FixedRate == 0
+}
+
+func newJobState(ctx TriggerContext) JobState {
+	return JobState{
+		LastCompletionTime:         ctx.LastCompletionTime(),
+		LastExecutionTime:          ctx.LastExecutionTime(),
+		LastTriggeredExecutionTime: ctx.LastTriggeredExecutionTime(),
+	}
+}
+
+// findStoredJob returns the job with the given name in the job store, or nil if there is not any.
+func findStoredJob(store JobStore, name string) (*StoredJob, error) {
+	storedJobs, err := store.LoadJobs()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storedJob := range storedJobs {
+		if storedJob.Job.Name == name {
+			return &storedJob, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// restoreStoredJob puts the previous record of a job back into the job store, or deletes the job
+// if there is not any.
+func restoreStoredJob(store JobStore, name string, previousJob *StoredJob) error {
+	if previousJob == nil {
+		return store.DeleteJob(name)
+	}
+
+	if err := store.SaveJob(previousJob.Job); err != nil {
+		return err
+	}
+
+	return store.SaveJobState(name, previousJob.State)
+}
+
+// jobStoreListener keeps the state of the jobs in the job store up to date.
+type jobStoreListener struct {
+	TaskListenerAdapter
+	scheduler *SimpleTaskScheduler
+}
+
+func (listener jobStoreListener) AfterRun(event TaskEvent) {
+	if event.Manual {
+		return
+	}
+
+	namedTask, store := listener.scheduler.getJob(event.TaskName, event.TaskID)
+
+	if namedTask == nil || store == nil {
+		return
+	}
+
+	var err error
+
+	if namedTask.job.isOneShot() {
+		err = store.DeleteJob(namedTask.job.Name)
+	} else if triggerTask, ok := namedTask.scheduledTask.(*TriggerTask); ok {
+		triggerTask.triggerContextMu.RLock()
+		state := newJobState(triggerTask.triggerContext)
+		triggerTask.triggerContextMu.RUnlock()
+
+		err = store.SaveJobState(namedTask.job.Name, state)
+	} else {
+		err = store.SaveJobState(namedTask.job.Name, JobState{
+			LastCompletionTime:         event.StartTime.Add(event.Duration),
+			LastExecutionTime:          event.StartTime,
+			LastTriggeredExecutionTime: event.ScheduledTime,
+		})
+	}
+
+	if err != nil {
+		listener.scheduler.loggerHolder.get().Error("job state could not be saved",
+			taskLogAttrs(event.TaskID, event.TaskName, slog.Any("error", err))...)
+	}
+}
+
+func (listener jobStoreListener) OnCancel(event TaskEvent) {
+	namedTask, store := listener.scheduler.getJob(event.TaskName, event.TaskID)
+
+	// the job might have been scheduled again with the same name in the meantime
+	if namedTask == nil || store == nil || !namedTask.scheduledTask.IsCancelled() {
+		return
+	}
+
+	if err := store.DeleteJob(namedTask.job.Name); err != nil {
+		listener.scheduler.loggerHolder.get().Error("job could not be deleted",
+			taskLogAttrs(event.TaskID, event.TaskName, slog.Any("error", err))...)
+	}
+}
@@ -0,0 +1,362 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type memoryJobStore struct {
+	jobs map[string]StoredJob
+	mu   sync.Mutex
+}
+
+func newMemoryJobStore() *memoryJobStore {
+	return &memoryJobStore{
+		jobs: make(map[string]StoredJob),
+	}
+}
+
+func (store *memoryJobStore) SaveJob(job Job) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.jobs[job.Name] = StoredJob{Job: job}
+	return nil
+}
+
+func (store *memoryJobStore) SaveJobState(name string, state JobState) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if storedJob, ok := store.jobs[name]; ok {
+		storedJob.State = state
+		store.jobs[name] = storedJob
+	}
+
+	return nil
+}
+
+func (store *memoryJobStore) DeleteJob(name string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	delete(store.jobs, name)
+	return nil
+}
+
+func (store *memoryJobStore) LoadJobs() ([]StoredJob, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	storedJobs := make([]StoredJob, 0, len(store.jobs))
+
+	for _, storedJob := range store.jobs {
+		storedJobs = append(storedJobs, storedJob)
+	}
+
+	return storedJobs, nil
+}
+
+func (store *memoryJobStore) get(name string) (StoredJob, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	storedJob, ok := store.jobs[name]
+	return storedJob, ok
+}
+
+func TestJob_Validate(t *testing.T) {
+	assert.EqualError(t, Job{TaskName: "task"}.validate(), "job name cannot be empty")
+	assert.EqualError(t, Job{Name: "job"}.validate(), "task name of job cannot be empty")
+	assert.EqualError(t, Job{Name: "job", TaskName: "task", Cron: "* * * * * *", FixedRate: time.Second}.validate(),
+		"only one of cron, fixed delay and fixed rate can be given for a job")
+	assert.EqualError(t, Job{Name: "job", TaskName: "task", FixedDelay: -time.Second}.validate(),
+		"fixed delay and fixed rate of a job cannot be negative")
+	assert.Nil(t, Job{Name: "job", TaskName: "task", FixedDelay: time.Second}.validate())
+}
+
+func TestJob_OptionsWithLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	startTime := time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)
+	job := Job{Name: "job", TaskName: "task", StartTime: startTime, Location: "America/New_York"}
+
+	schedulerTask, err := CreateSchedulerTask(func(ctx context.Context) {}, job.options()...)
+	assert.Nil(t, err)
+	assert.Equal(t, newYork, schedulerTask.location)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local), schedulerTask.startTime)
+
+	job.Location = ""
+
+	schedulerTask, err = CreateSchedulerTask(func(ctx context.Context) {}, job.options()...)
+	assert.Nil(t, err)
+	assert.Equal(t, time.UTC, schedulerTask.location)
+	assert.Equal(t, time.Date(2024, 1, 1, 15, 0, 0, 0, time.Local), schedulerTask.startTime)
+}
+
+func TestSimpleTaskScheduler_RegisterTask(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.EqualError(t, scheduler.RegisterTask("", func(ctx context.Context) {}), "task name cannot be empty")
+	assert.EqualError(t, scheduler.RegisterTask("task", nil), "task cannot be nil")
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+	assert.EqualError(t, scheduler.RegisterTask("task", func(ctx context.Context) {}),
+		"task with name task is already registered")
+}
+
+func TestSimpleTaskScheduler_ScheduleJobWithoutRegisteredTask(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	store := newMemoryJobStore()
+	scheduler.SetJobStore(store)
+
+	task, err := scheduler.ScheduleJob(Job{Name: "job", TaskName: "task", FixedRate: time.Second})
+	assert.Nil(t, task)
+	assert.EqualError(t, err, "no task registered with name task")
+
+	_, ok := store.get("job")
+	assert.False(t, ok)
+}
+
+func TestSimpleTaskScheduler_ScheduleJobKeepsStoredJobOnFailure(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	store := newMemoryJobStore()
+	scheduler.SetJobStore(store)
+
+	storedJob := Job{Name: "job", TaskName: "task", Cron: "0 0 * * * *"}
+	state := JobState{LastTriggeredExecutionTime: time.Now().Truncate(time.Hour)}
+	assert.Nil(t, store.SaveJob(storedJob))
+	assert.Nil(t, store.SaveJobState("job", state))
+
+	_, err := scheduler.ScheduleJob(Job{Name: "job", TaskName: "other-task", FixedRate: time.Second})
+	assert.EqualError(t, err, "no task registered with name other-task")
+
+	restoredJob, ok := store.get("job")
+	assert.True(t, ok)
+	assert.Equal(t, storedJob, restoredJob.Job)
+	assert.Equal(t, state, restoredJob.State)
+}
+
+func TestSimpleTaskScheduler_ScheduleJobWithDuplicateName(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	_, err := scheduler.ScheduleJob(Job{Name: "job", TaskName: "task", FixedRate: time.Hour})
+	assert.Nil(t, err)
+
+	_, err = scheduler.ScheduleJob(Job{Name: "job", TaskName: "task", FixedRate: time.Hour})
+	assert.EqualError(t, err, "task with name job is already scheduled")
+}
+
+func TestSimpleTaskScheduler_ScheduleOneShotJob(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	store := newMemoryJobStore()
+	scheduler.SetJobStore(store)
+
+	var counter int32
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}))
+
+	_, err := scheduler.ScheduleJob(Job{Name: "job", TaskName: "task", StartTime: time.Now().Add(500 * time.Millisecond)})
+	assert.Nil(t, err)
+
+	_, ok := store.get("job")
+	assert.True(t, ok)
+
+	<-time.After(1500 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+
+	_, ok = store.get("job")
+	assert.False(t, ok)
+}
+
+func TestSimpleTaskScheduler_ScheduleJobSavesState(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	store := newMemoryJobStore()
+	scheduler.SetJobStore(store)
+
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	_, err := scheduler.ScheduleJob(Job{Name: "job", TaskName: "task", Cron: "* * * * * *"})
+	assert.Nil(t, err)
+
+	<-time.After(1500 * time.Millisecond)
+
+	storedJob, ok := store.get("job")
+	assert.True(t, ok)
+	assert.Equal(t, "* * * * * *", storedJob.Job.Cron)
+	assert.False(t, storedJob.State.LastTriggeredExecutionTime.IsZero())
+	assert.False(t, storedJob.State.LastCompletionTime.IsZero())
+}
+
+func TestSimpleTaskScheduler_CancelJob(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	store := newMemoryJobStore()
+	scheduler.SetJobStore(store)
+
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	task, err := scheduler.ScheduleJob(Job{Name: "job", TaskName: "task", FixedDelay: time.Hour})
+	assert.Nil(t, err)
+
+	task.Cancel()
+
+	<-time.After(200 * time.Millisecond)
+
+	_, ok := store.get("job")
+	assert.False(t, ok)
+}
+
+func TestSimpleTaskScheduler_LoadJobs(t *testing.T) {
+	store, err := NewFileJobStore(filepath.Join(t.TempDir(), "jobs.log"))
+	assert.Nil(t, err)
+
+	lastTriggeredTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	assert.Nil(t, store.SaveJob(Job{Name: "cron-job", TaskName: "task", Cron: "0 0 0 1 1 *"}))
+	assert.Nil(t, store.SaveJobState("cron-job", JobState{LastTriggeredExecutionTime: lastTriggeredTime}))
+	assert.Nil(t, store.SaveJob(Job{Name: "rate-job", TaskName: "task", FixedRate: time.Hour}))
+	assert.Nil(t, store.SaveJob(Job{Name: "unknown-job", TaskName: "unknown", FixedRate: time.Hour}))
+
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	scheduler.SetJobStore(store)
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	err = scheduler.LoadJobs()
+	assert.EqualError(t, err, "job unknown-job could not be loaded : no task registered with name unknown")
+
+	cronTask, ok := scheduler.GetTask("cron-job")
+	assert.True(t, ok)
+
+	triggerTask := cronTask.(*TriggerTask)
+	triggerTask.triggerContextMu.RLock()
+	assert.True(t, lastTriggeredTime.Equal(triggerTask.triggerContext.LastTriggeredExecutionTime()))
+	triggerTask.triggerContextMu.RUnlock()
+
+	_, ok = scheduler.GetTask("rate-job")
+	assert.True(t, ok)
+
+	_, ok = scheduler.GetTask("unknown-job")
+	assert.False(t, ok)
+}
+
+func TestSimpleTaskScheduler_LoadJobsWithoutJobStore(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.EqualError(t, scheduler.LoadJobs(), "no job store is set")
+}
+
+type failingJobStore struct {
+	memoryJobStore
+}
+
+func (store *failingJobStore) SaveJob(job Job) error {
+	return errors.New("store is not available")
+}
+
+func TestSimpleTaskScheduler_ScheduleJobWithFailingJobStore(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	scheduler.SetJobStore(&failingJobStore{})
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	task, err := scheduler.ScheduleJob(Job{Name: "job", TaskName: "task", FixedRate: time.Hour})
+	assert.Nil(t, task)
+	assert.EqualError(t, err, "store is not available")
+
+	_, ok := scheduler.GetTask("job")
+	assert.False(t, ok)
+}
+
+func loadMissedCronJob(t *testing.T, policy MisfirePolicy) (*SimpleTaskScheduler, *int32) {
+	store := newMemoryJobStore()
+	lastTriggeredTime := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+
+	assert.Nil(t, store.SaveJob(Job{Name: "job", TaskName: "task", Cron: "0 0 * * * *", Location: "UTC", MisfirePolicy: policy}))
+	assert.Nil(t, store.SaveJobState("job", JobState{
+		LastCompletionTime:         lastTriggeredTime.Add(time.Second),
+		LastExecutionTime:          lastTriggeredTime,
+		LastTriggeredExecutionTime: lastTriggeredTime,
+	}))
+
+	scheduler := NewSimpleTaskScheduler(nil)
+	scheduler.SetJobStore(store)
+
+	var counter int32
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}))
+
+	assert.Nil(t, scheduler.LoadJobs())
+
+	<-time.After(500 * time.Millisecond)
+	return scheduler, &counter
+}
+
+func TestSimpleTaskScheduler_LoadJobsRunsMissedExecutionOnce(t *testing.T) {
+	scheduler, counter := loadMissedCronJob(t, MisfirePolicyRunOnce)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(counter))
+
+	history, err := scheduler.History("job")
+	assert.Nil(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, ExecutionSucceeded, history[0].Outcome)
+
+	task, _ := scheduler.GetTask("job")
+	triggerTask := task.(*TriggerTask)
+	triggerTask.triggerContextMu.RLock()
+	assert.True(t, time.Now().Truncate(time.Hour).Add(-2*time.Hour).Equal(triggerTask.triggerContext.LastTriggeredExecutionTime()))
+	assert.True(t, time.Now().Truncate(time.Hour).Add(time.Hour).Equal(triggerTask.nextTriggerTime))
+	triggerTask.triggerContextMu.RUnlock()
+}
+
+func TestSimpleTaskScheduler_LoadJobsRunsAllMissedExecutions(t *testing.T) {
+	scheduler, counter := loadMissedCronJob(t, MisfirePolicyRunAll)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(counter))
+
+	task, _ := scheduler.GetTask("job")
+	triggerTask := task.(*TriggerTask)
+	triggerTask.triggerContextMu.RLock()
+	assert.True(t, time.Now().Truncate(time.Hour).Equal(triggerTask.triggerContext.LastTriggeredExecutionTime()))
+	assert.True(t, time.Now().Truncate(time.Hour).Add(time.Hour).Equal(triggerTask.nextTriggerTime))
+	triggerTask.triggerContextMu.RUnlock()
+}
+
+func TestSimpleTaskScheduler_LoadJobsSkipsMissedExecutions(t *testing.T) {
+	scheduler, counter := loadMissedCronJob(t, MisfirePolicySkip)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(counter))
+
+	history, err := scheduler.History("job")
+	assert.Nil(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, ExecutionSkipped, history[0].Outcome)
+	assert.ErrorIs(t, history[0].Err, ErrExecutionMisfired)
+}
@@ -0,0 +1,206 @@
+package chrono
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	jobLogOpSave   = "save"
+	jobLogOpState  = "state"
+	jobLogOpDelete = "delete"
+)
+
+type jobLogEntry struct {
+	Op    string    `json:"op"`
+	Name  string    `json:"name"`
+	Job   *Job      `json:"job,omitempty"`
+	State *JobState `json:"state,omitempty"`
+}
+
+// FileJobStore is a JobStore which keeps the jobs in a file as an append-only log of JSON lines.
+// The log can be compacted by calling Compact.
+type FileJobStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileJobStore(path string) (*FileJobStore, error) {
+	if path == "" {
+		return nil, errors.New("path cannot be empty")
+	}
+
+	return &FileJobStore{
+		path: path,
+	}, nil
+}
+
+func (store *FileJobStore) SaveJob(job Job) error {
+	if err := job.validate(); err != nil {
+		return err
+	}
+
+	return store.append(jobLogEntry{Op: jobLogOpSave, Name: job.Name, Job: &job})
+}
+
+func (store *FileJobStore) SaveJobState(name string, state JobState) error {
+	return store.append(jobLogEntry{Op: jobLogOpState, Name: name, State: &state})
+}
+
+func (store *FileJobStore) DeleteJob(name string) error {
+	return store.append(jobLogEntry{Op: jobLogOpDelete, Name: name})
+}
+
+func (store *FileJobStore) LoadJobs() ([]StoredJob, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return store.load()
+}
+
+// Compact rewrites the log so that it only contains the current jobs and their states.
+func (store *FileJobStore) Compact() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	storedJobs, err := store.load()
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(file.Name())
+
+	encoder := json.NewEncoder(file)
+
+	for _, storedJob := range storedJobs {
+		job, state := storedJob.Job, storedJob.State
+
+		if err = encoder.Encode(jobLogEntry{Op: jobLogOpSave, Name: job.Name, Job: &job}); err != nil {
+			break
+		}
+
+		if err = encoder.Encode(jobLogEntry{Op: jobLogOpState, Name: job.Name, State: &state}); err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), store.path)
+}
+
+func (store *FileJobStore) append(entry jobLogEntry) error {
+	data, err := json.Marshal(entry)
+
+	if err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	file, err := os.OpenFile(store.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(data, '\n'))
+
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func (store *FileJobStore) load() ([]StoredJob, error) {
+	file, err := os.Open(store.path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	jobs := make(map[string]*StoredJob)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry jobLogEntry
+
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("job log entry at line %d is invalid : %w", line, err)
+		}
+
+		switch entry.Op {
+		case jobLogOpSave:
+			if entry.Job == nil {
+				return nil, fmt.Errorf("job log entry at line %d has no job", line)
+			}
+
+			jobs[entry.Name] = &StoredJob{Job: *entry.Job}
+		case jobLogOpState:
+			if storedJob, ok := jobs[entry.Name]; ok && entry.State != nil {
+				storedJob.State = *entry.State
+			}
+		case jobLogOpDelete:
+			delete(jobs, entry.Name)
+		default:
+			return nil, fmt.Errorf("job log entry at line %d has unknown operation %s", line, entry.Op)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	storedJobs := make([]StoredJob, 0, len(jobs))
+
+	for _, storedJob := range jobs {
+		storedJobs = append(storedJobs, *storedJob)
+	}
+
+	sort.Slice(storedJobs, func(i, j int) bool {
+		return storedJobs[i].Job.Name < storedJobs[j].Job.Name
+	})
+
+	return storedJobs, nil
+}
@@ -0,0 +1,199 @@
+package chrono
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const DefaultSQLJobTableName = "chrono_jobs"
+
+var sqlIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type SQLJobStoreOption func(store *SQLJobStore) error
+
+// WithSQLTableName sets the name of the table the jobs are kept in.
+func WithSQLTableName(tableName string) SQLJobStoreOption {
+	return func(store *SQLJobStore) error {
+		if !sqlIdentifierRegexp.MatchString(tableName) {
+			return fmt.Errorf("invalid table name %s", tableName)
+		}
+
+		store.tableName = tableName
+		return nil
+	}
+}
+
+// WithSQLDollarPlaceholders makes the store use $1, $2... placeholders instead of ?,
+// which is required by databases such as PostgreSQL.
+func WithSQLDollarPlaceholders() SQLJobStoreOption {
+	return func(store *SQLJobStore) error {
+		store.dollarPlaceholders = true
+		return nil
+	}
+}
+
+// SQLJobStore is a JobStore which keeps the jobs in a database table.
+// The table can be created by calling CreateTable.
+type SQLJobStore struct {
+	db                 *sql.DB
+	tableName          string
+	dollarPlaceholders bool
+}
+
+func NewSQLJobStore(db *sql.DB, options ...SQLJobStoreOption) (*SQLJobStore, error) {
+	if db == nil {
+		return nil, errors.New("db cannot be nil")
+	}
+
+	store := &SQLJobStore{
+		db:        db,
+		tableName: DefaultSQLJobTableName,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err := option(store); err != nil {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// CreateTable creates the table of the jobs if it does not exist.
+func (store *SQLJobStore) CreateTable() error {
+	_, err := store.db.Exec(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (name VARCHAR(255) PRIMARY KEY, definition TEXT NOT NULL, state TEXT)",
+		store.tableName,
+	))
+	return err
+}
+
+func (store *SQLJobStore) SaveJob(job Job) error {
+	if err := job.validate(); err != nil {
+		return err
+	}
+
+	definition, err := json.Marshal(job)
+
+	if err != nil {
+		return err
+	}
+
+	tx, err := store.db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRow(store.query("SELECT COUNT(*) FROM %s WHERE name = ?"), job.Name).Scan(&count)
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		_, err = tx.Exec(store.query("INSERT INTO %s (name, definition, state) VALUES (?, ?, NULL)"), job.Name, string(definition))
+	} else {
+		_, err = tx.Exec(store.query("UPDATE %s SET definition = ?, state = NULL WHERE name = ?"), string(definition), job.Name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (store *SQLJobStore) SaveJobState(name string, state JobState) error {
+	data, err := json.Marshal(state)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = store.db.Exec(store.query("UPDATE %s SET state = ? WHERE name = ?"), string(data), name)
+	return err
+}
+
+func (store *SQLJobStore) DeleteJob(name string) error {
+	_, err := store.db.Exec(store.query("DELETE FROM %s WHERE name = ?"), name)
+	return err
+}
+
+func (store *SQLJobStore) LoadJobs() ([]StoredJob, error) {
+	rows, err := store.db.Query(store.query("SELECT name, definition, state FROM %s ORDER BY name"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	storedJobs := make([]StoredJob, 0)
+
+	for rows.Next() {
+		var (
+			name       string
+			definition string
+			state      sql.NullString
+			storedJob  StoredJob
+		)
+
+		if err = rows.Scan(&name, &definition, &state); err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal([]byte(definition), &storedJob.Job); err != nil {
+			return nil, fmt.Errorf("definition of job %s is invalid : %w", name, err)
+		}
+
+		if state.Valid && state.String != "" {
+			if err = json.Unmarshal([]byte(state.String), &storedJob.State); err != nil {
+				return nil, fmt.Errorf("state of job %s is invalid : %w", name, err)
+			}
+		}
+
+		storedJobs = append(storedJobs, storedJob)
+	}
+
+	return storedJobs, rows.Err()
+}
+
+func (store *SQLJobStore) query(format string) string {
+	return formatSQLQuery(format, store.tableName, store.dollarPlaceholders)
+}
+
+// formatSQLQuery puts the table name into the query, and replaces its ? placeholders
+// with $1, $2... if dollar placeholders are used.
+func formatSQLQuery(format string, tableName string, dollarPlaceholders bool) string {
+	query := fmt.Sprintf(format, tableName)
+
+	if !dollarPlaceholders {
+		return query
+	}
+
+	result := make([]byte, 0, len(query)+8)
+	index := 0
+
+	for i := 0; i < len(query); i++ {
+		if query[i] == '?' {
+			index++
+			result = append(result, fmt.Sprintf("$%d", index)...)
+			continue
+		}
+
+		result = append(result, query[i])
+	}
+
+	return string(result)
+}
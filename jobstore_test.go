@@ -0,0 +1,77 @@
+package chrono
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewFileJobStore_WithEmptyPath(t *testing.T) {
+	store, err := NewFileJobStore("")
+	assert.Nil(t, store)
+	assert.EqualError(t, err, "path cannot be empty")
+}
+
+func TestFileJobStore_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.log")
+	store, err := NewFileJobStore(path)
+	assert.Nil(t, err)
+
+	assert.Nil(t, store.SaveJob(Job{Name: "a", TaskName: "task", FixedRate: time.Second}))
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, store.SaveJobState("a", JobState{LastTriggeredExecutionTime: time.Now()}))
+	}
+
+	assert.Nil(t, store.SaveJob(Job{Name: "b", TaskName: "task"}))
+	assert.Nil(t, store.DeleteJob("b"))
+
+	storedJobs, err := store.LoadJobs()
+	assert.Nil(t, err)
+
+	assert.Nil(t, store.Compact())
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+
+	compactedJobs, err := store.LoadJobs()
+	assert.Nil(t, err)
+	assert.Len(t, compactedJobs, 1)
+	assert.Equal(t, storedJobs[0].Job, compactedJobs[0].Job)
+	assert.True(t, storedJobs[0].State.LastTriggeredExecutionTime.Equal(compactedJobs[0].State.LastTriggeredExecutionTime))
+}
+
+func TestFileJobStore_WithInvalidLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.log")
+	assert.Nil(t, os.WriteFile(path, []byte("{\"op\":\"save\",\"name\":\"a\"}\n"), 0o644))
+
+	store, err := NewFileJobStore(path)
+	assert.Nil(t, err)
+
+	_, err = store.LoadJobs()
+	assert.EqualError(t, err, "job log entry at line 1 has no job")
+}
+
+func TestNewSQLJobStore_WithInvalidTableName(t *testing.T) {
+	store, err := NewSQLJobStore(&sql.DB{}, WithSQLTableName("jobs; DROP TABLE jobs"))
+	assert.Nil(t, store)
+	assert.EqualError(t, err, "invalid table name jobs; DROP TABLE jobs")
+}
+
+func TestNewSQLJobStore_WithoutDB(t *testing.T) {
+	store, err := NewSQLJobStore(nil)
+	assert.Nil(t, store)
+	assert.EqualError(t, err, "db cannot be nil")
+}
+
+func TestSQLJobStore_WithDollarPlaceholders(t *testing.T) {
+	store, err := NewSQLJobStore(&sql.DB{}, WithSQLDollarPlaceholders())
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE chrono_jobs SET state = $1 WHERE name = $2",
+		store.query("UPDATE %s SET state = ? WHERE name = ?"))
+}
@@ -0,0 +1,94 @@
+// Package jobstoretest implements support for testing the implementations of chrono.JobStore.
+package jobstoretest
+
+import (
+	"codnect.io/chrono"
+	"testing"
+	"time"
+)
+
+// TestJobStore tests that the given empty job store saves, updates, deletes and loads jobs and their states
+// correctly. The jobs must be loaded ordered by their names.
+func TestJobStore(t *testing.T, store chrono.JobStore) {
+	t.Helper()
+
+	storedJobs, err := store.LoadJobs()
+
+	if err != nil {
+		t.Fatalf("jobs cannot be loaded : %v", err)
+	}
+
+	if len(storedJobs) != 0 {
+		t.Fatalf("job store must be empty, actual: %d jobs", len(storedJobs))
+	}
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := chrono.JobState{
+		LastCompletionTime:         startTime.Add(2 * time.Second),
+		LastExecutionTime:          startTime.Add(time.Second),
+		LastTriggeredExecutionTime: startTime,
+	}
+
+	mustSucceed(t, store.SaveJob(chrono.Job{Name: "b", TaskName: "task", Cron: "0 * * * * *", Location: "UTC"}))
+	mustSucceed(t, store.SaveJob(chrono.Job{Name: "a", TaskName: "task", FixedDelay: time.Minute, StartTime: startTime}))
+	mustSucceed(t, store.SaveJob(chrono.Job{Name: "c", TaskName: "task"}))
+	mustSucceed(t, store.SaveJobState("b", state))
+	mustSucceed(t, store.DeleteJob("c"))
+
+	storedJobs = loadJobs(t, store, 2)
+
+	if job := storedJobs[0].Job; job.Name != "a" || job.FixedDelay != time.Minute || !startTime.Equal(job.StartTime) {
+		t.Errorf("unexpected job: %+v", job)
+	}
+
+	if !storedJobs[0].State.LastTriggeredExecutionTime.IsZero() {
+		t.Errorf("job a must not have any state, actual: %+v", storedJobs[0].State)
+	}
+
+	if job := storedJobs[1].Job; job.Name != "b" || job.Cron != "0 * * * * *" || job.Location != "UTC" {
+		t.Errorf("unexpected job: %+v", job)
+	}
+
+	if stored := storedJobs[1].State; !state.LastCompletionTime.Equal(stored.LastCompletionTime) ||
+		!state.LastExecutionTime.Equal(stored.LastExecutionTime) ||
+		!state.LastTriggeredExecutionTime.Equal(stored.LastTriggeredExecutionTime) {
+		t.Errorf("unexpected state of job b: %+v", stored)
+	}
+
+	// saving a job again replaces it and resets its state
+	mustSucceed(t, store.SaveJob(chrono.Job{Name: "b", TaskName: "task", FixedRate: time.Hour}))
+
+	storedJobs = loadJobs(t, store, 2)
+
+	if job := storedJobs[1].Job; job.FixedRate != time.Hour || job.Cron != "" {
+		t.Errorf("unexpected job: %+v", job)
+	}
+
+	if !storedJobs[1].State.LastTriggeredExecutionTime.IsZero() {
+		t.Errorf("state of job b must be reset, actual: %+v", storedJobs[1].State)
+	}
+}
+
+func mustSucceed(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("unexpected error : %v", err)
+	}
+}
+
+func loadJobs(t *testing.T, store chrono.JobStore, count int) []chrono.StoredJob {
+	t.Helper()
+
+	storedJobs, err := store.LoadJobs()
+
+	if err != nil {
+		t.Fatalf("jobs cannot be loaded : %v", err)
+	}
+
+	if len(storedJobs) != count {
+		t.Fatalf("number of stored jobs must be %d, actual: %d", count, len(storedJobs))
+	}
+
+	return storedJobs
+}
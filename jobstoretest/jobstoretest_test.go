@@ -0,0 +1,15 @@
+package jobstoretest
+
+import (
+	"codnect.io/chrono"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileJobStore(t *testing.T) {
+	store, err := chrono.NewFileJobStore(filepath.Join(t.TempDir(), "jobs.log"))
+	assert.Nil(t, err)
+
+	TestJobStore(t, store)
+}
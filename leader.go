@@ -0,0 +1,159 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultLeaseDuration is how long the lease of a leader lasts unless WithLeaseDuration is used.
+const DefaultLeaseDuration = 15 * time.Second
+
+// LeaderCallbacks are called by a LeaderElector as the leadership is gained and lost.
+type LeaderCallbacks struct {
+	OnElected func()
+	OnRevoked func()
+	// OnError is called with the errors which do not stop the election.
+	OnError func(err error)
+}
+
+// LeaderElector elects one of the instances sharing it as the leader.
+type LeaderElector interface {
+	// Campaign campaigns for the leadership until the context is cancelled. It resigns from
+	// the leadership before returning if it is the leader.
+	Campaign(ctx context.Context, callbacks LeaderCallbacks) error
+}
+
+type LeaseElectorOption func(elector *LeaseElector) error
+
+// WithLeaseDuration sets how long the lease of a leader lasts if it is not renewed.
+func WithLeaseDuration(duration time.Duration) LeaseElectorOption {
+	return func(elector *LeaseElector) error {
+		if duration <= 0 {
+			return errors.New("lease duration must be greater than zero")
+		}
+
+		elector.leaseDuration = duration
+		return nil
+	}
+}
+
+// WithLeaseRetryInterval sets how often the lease is renewed by the leader and tried to be
+// acquired by the others. It is the third of the lease duration by default.
+func WithLeaseRetryInterval(interval time.Duration) LeaseElectorOption {
+	return func(elector *LeaseElector) error {
+		if interval <= 0 {
+			return errors.New("lease retry interval must be greater than zero")
+		}
+
+		elector.retryInterval = interval
+		return nil
+	}
+}
+
+// LeaseElector is a LeaderElector electing the instance holding the lease as the leader.
+// The lease is kept as a lock of the given locker, such as SQLLocker for the instances sharing
+// a database, or MemoryLocker in tests.
+type LeaseElector struct {
+	locker        Locker
+	name          string
+	leaseDuration time.Duration
+	retryInterval time.Duration
+}
+
+func NewLeaseElector(locker Locker, name string, options ...LeaseElectorOption) (*LeaseElector, error) {
+	if locker == nil {
+		return nil, errors.New("locker cannot be nil")
+	}
+
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+
+	elector := &LeaseElector{
+		locker:        locker,
+		name:          name,
+		leaseDuration: DefaultLeaseDuration,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err := option(elector); err != nil {
+			return nil, err
+		}
+	}
+
+	if elector.retryInterval == 0 {
+		elector.retryInterval = elector.leaseDuration / 3
+	}
+
+	if elector.retryInterval >= elector.leaseDuration {
+		return nil, errors.New("lease retry interval must be less than lease duration")
+	}
+
+	return elector, nil
+}
+
+func (elector *LeaseElector) Campaign(ctx context.Context, callbacks LeaderCallbacks) error {
+	var lease Lock
+
+	ticker := time.NewTicker(elector.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		if lease == nil {
+			acquiredLease, err := elector.locker.Acquire(ctx, elector.name, elector.leaseDuration)
+
+			if err == nil {
+				lease = acquiredLease
+				callbacks.elected()
+			} else if !errors.Is(err, ErrLockNotAcquired) && ctx.Err() == nil {
+				callbacks.error(err)
+			}
+		} else if err := lease.Extend(ctx, elector.leaseDuration); err != nil && ctx.Err() == nil {
+			// the lease might expire before it is renewed, so the leadership is given up at once
+			callbacks.error(err)
+			elector.resign(lease, callbacks)
+			lease = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lease != nil {
+				elector.resign(lease, callbacks)
+			}
+
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (elector *LeaseElector) resign(lease Lock, callbacks LeaderCallbacks) {
+	callbacks.revoked()
+
+	if err := lease.Release(context.Background()); err != nil {
+		callbacks.error(err)
+	}
+}
+
+func (callbacks LeaderCallbacks) error(err error) {
+	if callbacks.OnError != nil {
+		callbacks.OnError(err)
+	}
+}
+
+func (callbacks LeaderCallbacks) elected() {
+	if callbacks.OnElected != nil {
+		callbacks.OnElected()
+	}
+}
+
+func (callbacks LeaderCallbacks) revoked() {
+	if callbacks.OnRevoked != nil {
+		callbacks.OnRevoked()
+	}
+}
@@ -0,0 +1,280 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type expiringLocker struct {
+	Locker
+	expired atomic.Bool
+}
+
+func (locker *expiringLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	if locker.expired.Load() {
+		return nil, ErrLockNotAcquired
+	}
+
+	lock, err := locker.Locker.Acquire(ctx, key, ttl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &expiringLock{Lock: lock, locker: locker}, nil
+}
+
+type expiringLock struct {
+	Lock
+	locker *expiringLocker
+}
+
+func (lock *expiringLock) Extend(ctx context.Context, ttl time.Duration) error {
+	if lock.locker.expired.Load() {
+		return errors.New("lock is not held anymore")
+	}
+
+	return lock.Lock.Extend(ctx, ttl)
+}
+
+func newTestLeaseElector(t *testing.T, locker Locker) *LeaseElector {
+	elector, err := NewLeaseElector(locker, "scheduler", WithLeaseDuration(300*time.Millisecond),
+		WithLeaseRetryInterval(50*time.Millisecond))
+	assert.Nil(t, err)
+	return elector
+}
+
+func TestNewLeaseElector(t *testing.T) {
+	_, err := NewLeaseElector(nil, "scheduler")
+	assert.EqualError(t, err, "locker cannot be nil")
+
+	_, err = NewLeaseElector(NewMemoryLocker(), "")
+	assert.EqualError(t, err, "name cannot be empty")
+
+	_, err = NewLeaseElector(NewMemoryLocker(), "scheduler", WithLeaseDuration(0))
+	assert.EqualError(t, err, "lease duration must be greater than zero")
+
+	_, err = NewLeaseElector(NewMemoryLocker(), "scheduler", WithLeaseDuration(time.Second),
+		WithLeaseRetryInterval(time.Second))
+	assert.EqualError(t, err, "lease retry interval must be less than lease duration")
+
+	elector, err := NewLeaseElector(NewMemoryLocker(), "scheduler", WithLeaseDuration(3*time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, elector.retryInterval)
+}
+
+func TestLeaseElector_Campaign(t *testing.T) {
+	locker := NewMemoryLocker()
+
+	var leaders int32
+	var elected [2]atomic.Bool
+	var cancels [2]context.CancelFunc
+	var done [2]chan struct{}
+
+	for i := 0; i < 2; i++ {
+		i := i
+		ctx, cancel := context.WithCancel(context.Background())
+		cancels[i] = cancel
+		done[i] = make(chan struct{})
+
+		go func() {
+			defer close(done[i])
+
+			newTestLeaseElector(t, locker).Campaign(ctx, LeaderCallbacks{
+				OnElected: func() {
+					atomic.AddInt32(&leaders, 1)
+					elected[i].Store(true)
+				},
+				OnRevoked: func() {
+					atomic.AddInt32(&leaders, -1)
+					elected[i].Store(false)
+				},
+			})
+		}()
+	}
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&leaders))
+	assert.True(t, locker.IsLocked("scheduler"))
+
+	leader := 0
+
+	if elected[1].Load() {
+		leader = 1
+	}
+
+	cancels[leader]()
+	<-done[leader]
+
+	assert.False(t, elected[leader].Load())
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&leaders))
+	assert.True(t, elected[1-leader].Load())
+
+	cancels[1-leader]()
+	<-done[1-leader]
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&leaders))
+	assert.False(t, locker.IsLocked("scheduler"))
+}
+
+func TestSimpleTaskScheduler_StartLeaderElection(t *testing.T) {
+	locker := NewMemoryLocker()
+
+	var counters [2]int32
+	var schedulers [2]*SimpleTaskScheduler
+
+	for i := 0; i < 2; i++ {
+		i := i
+		schedulers[i] = NewSimpleTaskScheduler(nil)
+		assert.Nil(t, schedulers[i].StartLeaderElection(newTestLeaseElector(t, locker)))
+
+		_, err := schedulers[i].ScheduleAtFixedRate(func(ctx context.Context) {
+			atomic.AddInt32(&counters[i], 1)
+		}, 100*time.Millisecond, WithName("task"))
+		assert.Nil(t, err)
+	}
+
+	<-time.After(500 * time.Millisecond)
+
+	leader := 0
+
+	if schedulers[1].IsLeader() {
+		leader = 1
+	}
+
+	follower := 1 - leader
+
+	assert.True(t, schedulers[leader].IsLeader())
+	assert.False(t, schedulers[follower].IsLeader())
+	assert.False(t, schedulers[follower].IsPaused())
+	assert.True(t, atomic.LoadInt32(&counters[leader]) >= 3)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counters[follower]))
+
+	<-schedulers[leader].Shutdown()
+	assert.False(t, schedulers[leader].IsLeader())
+
+	<-time.After(500 * time.Millisecond)
+
+	assert.True(t, schedulers[follower].IsLeader())
+	assert.True(t, atomic.LoadInt32(&counters[follower]) >= 3)
+
+	<-schedulers[follower].Shutdown()
+	assert.False(t, locker.IsLocked("scheduler"))
+}
+
+func TestSimpleTaskScheduler_StartLeaderElectionSkipsExecutionsMissedInStandby(t *testing.T) {
+	locker := NewMemoryLocker()
+
+	var counters [2]int32
+	var schedulers [2]*SimpleTaskScheduler
+
+	for i := 0; i < 2; i++ {
+		i := i
+		schedulers[i] = NewSimpleTaskScheduler(nil)
+		assert.Nil(t, schedulers[i].StartLeaderElection(newTestLeaseElector(t, locker)))
+
+		_, err := schedulers[i].ScheduleWithCron(func(ctx context.Context) {
+			atomic.AddInt32(&counters[i], 1)
+		}, "* * * * * *", WithName("task"), WithMisfirePolicy(MisfirePolicyRunAll))
+		assert.Nil(t, err)
+	}
+
+	<-time.After(3200 * time.Millisecond)
+
+	leader := 0
+
+	if schedulers[1].IsLeader() {
+		leader = 1
+	}
+
+	follower := 1 - leader
+
+	assert.True(t, atomic.LoadInt32(&counters[leader]) >= 2)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counters[follower]))
+
+	<-schedulers[leader].Shutdown()
+	<-time.After(500 * time.Millisecond)
+
+	assert.True(t, schedulers[follower].IsLeader())
+	assert.True(t, atomic.LoadInt32(&counters[follower]) <= 1)
+
+	<-time.After(1500 * time.Millisecond)
+	assert.True(t, atomic.LoadInt32(&counters[follower]) >= 1)
+
+	<-schedulers[follower].Shutdown()
+}
+
+func TestSimpleTaskScheduler_StartLeaderElectionWhenLeadershipIsLost(t *testing.T) {
+	locker := &expiringLocker{Locker: NewMemoryLocker()}
+
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	var counter int32
+	_, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 50*time.Millisecond)
+	assert.Nil(t, err)
+
+	assert.Nil(t, scheduler.StartLeaderElection(newTestLeaseElector(t, locker)))
+
+	<-time.After(200 * time.Millisecond)
+	assert.True(t, scheduler.IsLeader())
+
+	locker.expired.Store(true)
+	<-time.After(100 * time.Millisecond)
+
+	assert.False(t, scheduler.IsLeader())
+	value := atomic.LoadInt32(&counter)
+
+	<-time.After(200 * time.Millisecond)
+	assert.Equal(t, value, atomic.LoadInt32(&counter))
+
+	locker.expired.Store(false)
+	<-time.After(200 * time.Millisecond)
+	assert.True(t, scheduler.IsLeader())
+	assert.True(t, atomic.LoadInt32(&counter) > value)
+}
+
+func TestSimpleTaskScheduler_StartLeaderElectionWithPausedScheduler(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	scheduler.Pause()
+
+	var counter int32
+	_, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 50*time.Millisecond)
+	assert.Nil(t, err)
+
+	assert.Nil(t, scheduler.StartLeaderElection(newTestLeaseElector(t, NewMemoryLocker())))
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.True(t, scheduler.IsLeader())
+	assert.True(t, scheduler.IsPaused())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counter))
+
+	scheduler.Resume()
+	<-time.After(200 * time.Millisecond)
+	assert.True(t, atomic.LoadInt32(&counter) > 0)
+}
+
+func TestSimpleTaskScheduler_StartLeaderElectionTwice(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.EqualError(t, scheduler.StartLeaderElection(nil), "elector cannot be nil")
+	assert.Nil(t, scheduler.StartLeaderElection(newTestLeaseElector(t, NewMemoryLocker())))
+	assert.EqualError(t, scheduler.StartLeaderElection(newTestLeaseElector(t, NewMemoryLocker())),
+		"leader election is already started")
+}
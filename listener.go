@@ -0,0 +1,214 @@
+package chrono
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// taskEventQueueSize is the maximum number of the events waiting to be delivered to the listeners.
+// The events published while the queue is full are dropped.
+const taskEventQueueSize = 1024
+
+type TaskEvent struct {
+	TaskID        int
+	TaskName      string
+	ScheduledTime time.Time
+	StartTime     time.Time
+	Duration      time.Duration
+	// Err is the error the execution failed with, or the reason why the execution is skipped.
+	Err error
+	// Result is the result set by the execution with Execution.SetResult.
+	Result  interface{}
+	Attempt int
+	Manual  bool
+}
+
+type TaskListener interface {
+	OnSchedule(event TaskEvent)
+	OnReschedule(event TaskEvent)
+	BeforeRun(event TaskEvent)
+	AfterRun(event TaskEvent)
+	OnError(event TaskEvent)
+	OnSkip(event TaskEvent)
+	OnCancel(event TaskEvent)
+}
+
+// TaskListenerAdapter is an empty implementation of TaskListener,
+// which can be embedded to implement only the methods needed.
+type TaskListenerAdapter struct {
+}
+
+func (adapter TaskListenerAdapter) OnSchedule(event TaskEvent) {
+}
+
+func (adapter TaskListenerAdapter) OnReschedule(event TaskEvent) {
+}
+
+func (adapter TaskListenerAdapter) BeforeRun(event TaskEvent) {
+}
+
+func (adapter TaskListenerAdapter) AfterRun(event TaskEvent) {
+}
+
+func (adapter TaskListenerAdapter) OnError(event TaskEvent) {
+}
+
+func (adapter TaskListenerAdapter) OnSkip(event TaskEvent) {
+}
+
+func (adapter TaskListenerAdapter) OnCancel(event TaskEvent) {
+}
+
+type taskEventType int
+
+const (
+	taskEventSchedule taskEventType = iota
+	taskEventReschedule
+	taskEventBeforeRun
+	taskEventAfterRun
+	taskEventError
+	taskEventSkip
+	taskEventCancel
+)
+
+type publishedTaskEvent struct {
+	eventType taskEventType
+	event     TaskEvent
+}
+
+// taskEventDispatcher delivers the events to the listeners in a separate goroutine,
+// so that publishing an event never blocks, no matter how slow the listeners are.
+type taskEventDispatcher struct {
+	listeners     []TaskListener
+	events        []publishedTaskEvent
+	queueSize     int
+	dropped       int
+	dispatcherMu  sync.Mutex
+	signalChannel chan struct{}
+	closed        bool
+	loggerHolder  *loggerHolder
+}
+
+func newTaskEventDispatcher(loggerHolder *loggerHolder) *taskEventDispatcher {
+	return &taskEventDispatcher{
+		listeners:     make([]TaskListener, 0),
+		events:        make([]publishedTaskEvent, 0),
+		queueSize:     taskEventQueueSize,
+		signalChannel: make(chan struct{}, 1),
+		loggerHolder:  loggerHolder,
+	}
+}
+
+func (dispatcher *taskEventDispatcher) addListener(listener TaskListener) {
+	if listener == nil {
+		return
+	}
+
+	dispatcher.dispatcherMu.Lock()
+	defer dispatcher.dispatcherMu.Unlock()
+
+	if dispatcher.closed {
+		return
+	}
+
+	if len(dispatcher.listeners) == 0 {
+		go dispatcher.dispatch()
+	}
+
+	dispatcher.listeners = append(dispatcher.listeners, listener)
+}
+
+func (dispatcher *taskEventDispatcher) publish(eventType taskEventType, event TaskEvent) {
+	dispatcher.dispatcherMu.Lock()
+
+	defer dispatcher.dispatcherMu.Unlock()
+
+	if len(dispatcher.listeners) == 0 || dispatcher.closed {
+		return
+	}
+
+	if len(dispatcher.events) >= dispatcher.queueSize {
+		if dispatcher.dropped == 0 {
+			dispatcher.loggerHolder.get().Warn("task events are being dropped because listeners are slow",
+				slog.Int("queue_size", dispatcher.queueSize))
+		}
+
+		dispatcher.dropped++
+		return
+	}
+
+	dispatcher.events = append(dispatcher.events, publishedTaskEvent{eventType, event})
+
+	select {
+	case dispatcher.signalChannel <- struct{}{}:
+	default:
+	}
+}
+
+// close stops the dispatcher after the pending events are delivered.
+func (dispatcher *taskEventDispatcher) close() {
+	dispatcher.dispatcherMu.Lock()
+	defer dispatcher.dispatcherMu.Unlock()
+
+	if !dispatcher.closed {
+		dispatcher.closed = true
+		close(dispatcher.signalChannel)
+	}
+}
+
+func (dispatcher *taskEventDispatcher) dispatch() {
+	for {
+		_, ok := <-dispatcher.signalChannel
+
+		dispatcher.dispatcherMu.Lock()
+		events := dispatcher.events
+		listeners := dispatcher.listeners
+		dropped := dispatcher.dropped
+		dispatcher.events = make([]publishedTaskEvent, 0)
+		dispatcher.dropped = 0
+		dispatcher.dispatcherMu.Unlock()
+
+		if dropped != 0 {
+			dispatcher.loggerHolder.get().Warn("task events dropped because listeners are slow",
+				slog.Int("dropped_events", dropped))
+		}
+
+		for _, publishedEvent := range events {
+			for _, listener := range listeners {
+				dispatcher.notifyListener(listener, publishedEvent)
+			}
+		}
+
+		if !ok {
+			return
+		}
+	}
+}
+
+func (dispatcher *taskEventDispatcher) notifyListener(listener TaskListener, publishedEvent publishedTaskEvent) {
+	defer func() {
+		if value := recover(); value != nil {
+			event := publishedEvent.event
+			dispatcher.loggerHolder.get().Error("task listener panicked",
+				taskLogAttrs(event.TaskID, event.TaskName, slog.Any("panic", value))...)
+		}
+	}()
+
+	switch publishedEvent.eventType {
+	case taskEventSchedule:
+		listener.OnSchedule(publishedEvent.event)
+	case taskEventReschedule:
+		listener.OnReschedule(publishedEvent.event)
+	case taskEventBeforeRun:
+		listener.BeforeRun(publishedEvent.event)
+	case taskEventAfterRun:
+		listener.AfterRun(publishedEvent.event)
+	case taskEventError:
+		listener.OnError(publishedEvent.event)
+	case taskEventSkip:
+		listener.OnSkip(publishedEvent.event)
+	case taskEventCancel:
+		listener.OnCancel(publishedEvent.event)
+	}
+}
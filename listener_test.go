@@ -0,0 +1,264 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingTaskListener struct {
+	events   map[string][]TaskEvent
+	eventsMu sync.Mutex
+}
+
+func newRecordingTaskListener() *recordingTaskListener {
+	return &recordingTaskListener{
+		events: make(map[string][]TaskEvent),
+	}
+}
+
+func (listener *recordingTaskListener) record(name string, event TaskEvent) {
+	listener.eventsMu.Lock()
+	defer listener.eventsMu.Unlock()
+	listener.events[name] = append(listener.events[name], event)
+}
+
+func (listener *recordingTaskListener) get(name string) []TaskEvent {
+	listener.eventsMu.Lock()
+	defer listener.eventsMu.Unlock()
+	return listener.events[name]
+}
+
+func (listener *recordingTaskListener) OnSchedule(event TaskEvent) {
+	listener.record("OnSchedule", event)
+}
+
+func (listener *recordingTaskListener) OnReschedule(event TaskEvent) {
+	listener.record("OnReschedule", event)
+}
+
+func (listener *recordingTaskListener) BeforeRun(event TaskEvent) {
+	listener.record("BeforeRun", event)
+}
+
+func (listener *recordingTaskListener) AfterRun(event TaskEvent) {
+	listener.record("AfterRun", event)
+}
+
+func (listener *recordingTaskListener) OnError(event TaskEvent) {
+	listener.record("OnError", event)
+}
+
+func (listener *recordingTaskListener) OnSkip(event TaskEvent) {
+	listener.record("OnSkip", event)
+}
+
+func (listener *recordingTaskListener) OnCancel(event TaskEvent) {
+	listener.record("OnCancel", event)
+}
+
+func TestSimpleTaskExecutor_AddListener(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	listener := newRecordingTaskListener()
+	executor.AddListener(listener)
+
+	task, err := executor.schedule(func(ctx context.Context) {
+		<-time.After(50 * time.Millisecond)
+	}, 0, 200*time.Millisecond, true, WithName("test-task"))
+
+	assert.Nil(t, err)
+
+	<-time.After(500 * time.Millisecond)
+	task.Cancel()
+	task.Cancel()
+	<-time.After(100 * time.Millisecond)
+
+	assert.Len(t, listener.get("OnSchedule"), 1)
+	assert.Len(t, listener.get("BeforeRun"), 3)
+	assert.Len(t, listener.get("AfterRun"), 3)
+	assert.Len(t, listener.get("OnError"), 0)
+	assert.Len(t, listener.get("OnCancel"), 1)
+
+	event := listener.get("AfterRun")[0]
+	assert.Equal(t, task.(Identifiable).ID(), event.TaskID)
+	assert.Equal(t, "test-task", event.TaskName)
+	assert.False(t, event.ScheduledTime.IsZero())
+	assert.False(t, event.StartTime.Before(event.ScheduledTime))
+	assert.True(t, event.Duration >= 50*time.Millisecond)
+}
+
+func TestSimpleTaskExecutor_AddListener_FailedTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	listener := newRecordingTaskListener()
+	executor.AddListener(listener)
+
+	_, err := executor.Schedule(func(ctx context.Context) {
+		execution, ok := ExecutionFromContext(ctx)
+		assert.True(t, ok)
+		execution.Fail(errors.New("test error"))
+	}, 0)
+	assert.Nil(t, err)
+
+	_, err = executor.Schedule(func(ctx context.Context) {
+		panic("test panic")
+	}, 0)
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.Len(t, listener.get("OnError"), 2)
+	assert.Len(t, listener.get("AfterRun"), 2)
+	assert.Len(t, listener.get("OnCancel"), 0)
+
+	for _, event := range listener.get("OnError") {
+		assert.Error(t, event.Err)
+	}
+}
+
+func TestSimpleTaskExecutor_AddListener_SkippedTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	listener := newRecordingTaskListener()
+	executor.AddListener(listener)
+
+	task, err := executor.schedule(func(ctx context.Context) {
+	}, 100*time.Millisecond, 0, false, WithMisfirePolicy(MisfirePolicySkip))
+	assert.Nil(t, err)
+
+	task.(Pausable).Pause()
+	<-time.After(200 * time.Millisecond)
+	task.(Pausable).Resume()
+	<-time.After(100 * time.Millisecond)
+
+	assert.Len(t, listener.get("OnSkip"), 1)
+	assert.Len(t, listener.get("BeforeRun"), 0)
+}
+
+type slowTaskListener struct {
+	TaskListenerAdapter
+}
+
+func (listener *slowTaskListener) BeforeRun(event TaskEvent) {
+	<-time.After(1 * time.Hour)
+}
+
+func TestSimpleTaskExecutor_AddListener_SlowListener(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	executor.AddListener(&slowTaskListener{})
+
+	var counter int32
+	var counterMu sync.Mutex
+
+	task, err := executor.ScheduleAtFixedRate(func(ctx context.Context) {
+		counterMu.Lock()
+		counter++
+		counterMu.Unlock()
+	}, 0, 100*time.Millisecond)
+	assert.Nil(t, err)
+
+	<-time.After(550 * time.Millisecond)
+	task.Cancel()
+
+	counterMu.Lock()
+	defer counterMu.Unlock()
+	assert.True(t, counter >= 5,
+		"number of scheduled task execution must be at least 5, actual: %d", counter)
+}
+
+type blockingTaskListener struct {
+	TaskListenerAdapter
+	release chan struct{}
+	events  chan TaskEvent
+}
+
+func (listener *blockingTaskListener) OnSchedule(event TaskEvent) {
+	<-listener.release
+	listener.events <- event
+}
+
+func TestTaskEventDispatcher_DropsEventsWhenQueueIsFull(t *testing.T) {
+	holder := &loggerHolder{}
+	logger, buffer := newTestLogger(slog.LevelWarn)
+	holder.set(logger)
+
+	dispatcher := newTaskEventDispatcher(holder)
+	dispatcher.queueSize = 2
+
+	listener := &blockingTaskListener{release: make(chan struct{}), events: make(chan TaskEvent, 10)}
+	dispatcher.addListener(listener)
+
+	dispatcher.publish(taskEventSchedule, TaskEvent{TaskID: 1})
+	<-time.After(50 * time.Millisecond)
+
+	for id := 2; id <= 6; id++ {
+		dispatcher.publish(taskEventSchedule, TaskEvent{TaskID: id})
+	}
+
+	assert.Contains(t, buffer.String(), `msg="task events are being dropped because listeners are slow" queue_size=2`)
+
+	close(listener.release)
+	<-time.After(50 * time.Millisecond)
+	dispatcher.close()
+
+	assert.Len(t, listener.events, 3)
+	assert.Contains(t, buffer.String(), `msg="task events dropped because listeners are slow" dropped_events=3`)
+}
+
+type panickingTaskListener struct {
+	TaskListenerAdapter
+}
+
+func (listener *panickingTaskListener) OnSchedule(event TaskEvent) {
+	panic("listener failure")
+}
+
+func TestSimpleTaskExecutor_AddListener_PanickingListener(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	defer executor.Shutdown()
+
+	logger, buffer := newTestLogger(slog.LevelError)
+	executor.SetLogger(logger)
+	executor.AddListener(&panickingTaskListener{})
+
+	recordingListener := newRecordingTaskListener()
+	executor.AddListener(recordingListener)
+
+	_, err := executor.schedule(func(ctx context.Context) {}, 0, 0, false, WithName("task"))
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+
+	assert.Len(t, recordingListener.get("OnSchedule"), 1)
+	assert.Contains(t, buffer.String(), `msg="task listener panicked" task_id=1 task_name=task panic="listener failure"`)
+}
+
+func TestSimpleTaskScheduler_AddListener(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+	listener := newRecordingTaskListener()
+	scheduler.AddListener(listener)
+
+	task, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+	}, "* * * * * *", WithName("cron-task"))
+	assert.Nil(t, err)
+
+	<-time.After(2*time.Second + 100*time.Millisecond)
+
+	err = scheduler.RescheduleWithCron("cron-task", "*/2 * * * * *")
+	assert.Nil(t, err)
+
+	task.Cancel()
+	<-time.After(100 * time.Millisecond)
+
+	assert.Len(t, listener.get("OnSchedule"), 1)
+	assert.Len(t, listener.get("OnReschedule"), 1)
+	assert.Len(t, listener.get("OnCancel"), 1)
+	assert.True(t, len(listener.get("AfterRun")) >= 2)
+
+	for _, event := range listener.get("AfterRun") {
+		assert.Equal(t, task.(Identifiable).ID(), event.TaskID)
+		assert.Equal(t, "cron-task", event.TaskName)
+	}
+}
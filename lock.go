@@ -0,0 +1,213 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultLockTTL is how long the lock of a task is acquired for unless WithLockTTL is used.
+// The lock is extended periodically while the task is running.
+const DefaultLockTTL = 30 * time.Second
+
+// DefaultLockAtLeast is the minimum duration the lock of a periodic task is held for unless WithLockAtLeast is used,
+// so that the other instances firing the same execution a bit later because of the clock skew cannot run it again.
+// It is limited to the half of the interval between the executions of the task.
+const DefaultLockAtLeast = 5 * time.Second
+
+// ErrLockNotAcquired is the reason of the executions skipped because the lock of the task is
+// held by another instance.
+var ErrLockNotAcquired = errors.New("lock not acquired")
+
+// Lock is a lock acquired by a Locker.
+type Lock interface {
+	// Extend extends the lock so that it expires after the given duration.
+	Extend(ctx context.Context, ttl time.Duration) error
+	// Release releases the lock.
+	Release(ctx context.Context) error
+}
+
+// Locker acquires the locks of the tasks, which are shared by the instances running the same tasks.
+type Locker interface {
+	// Acquire acquires the lock with the given key for the given duration. It returns ErrLockNotAcquired
+	// if the lock is already held.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+type lockerHolder struct {
+	value atomic.Value
+}
+
+type storedLocker struct {
+	locker Locker
+}
+
+func (holder *lockerHolder) set(locker Locker) {
+	holder.value.Store(storedLocker{locker})
+}
+
+func (holder *lockerHolder) get() Locker {
+	stored, ok := holder.value.Load().(storedLocker)
+
+	if !ok {
+		return nil
+	}
+
+	return stored.locker
+}
+
+type lockerProvider interface {
+	locker() Locker
+}
+
+// lockHoldDuration returns the minimum duration the lock of an execution is held for. If it is not set by
+// WithLockAtLeast, the default duration is used for the tasks whose interval to the next execution is known.
+func lockHoldDuration(atLeast time.Duration, atLeastSet bool, interval time.Duration) time.Duration {
+	if atLeastSet || interval <= 0 {
+		return atLeast
+	}
+
+	return min(DefaultLockAtLeast, interval/2)
+}
+
+// acquireTaskLock acquires the lock of the task from the locker of the executor, and extends it
+// until the returned function is called. The tasks without a name are not locked.
+func acquireTaskLock(ctx context.Context, executor TaskExecutor, id int, name string,
+	ttl time.Duration, atLeast time.Duration) (func(), error) {
+	provider, ok := executor.(lockerProvider)
+
+	if !ok || provider.locker() == nil || name == "" {
+		return func() {}, nil
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultLockTTL
+	}
+
+	logger := loggerOf(executor)
+	acquiredTime := time.Now()
+	lock, err := provider.locker().Acquire(ctx, name, ttl)
+
+	if err != nil {
+		if !errors.Is(err, ErrLockNotAcquired) {
+			logger.Error("task lock could not be acquired", taskLogAttrs(id, name, slog.Any("error", err))...)
+		}
+
+		return nil, err
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(ttl / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := lock.Extend(context.Background(), ttl); err != nil {
+					logger.Error("task lock could not be extended", taskLogAttrs(id, name, slog.Any("error", err))...)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	release := func() {
+		once.Do(func() {
+			close(stop)
+			<-stopped
+
+			if err := lock.Release(context.Background()); err != nil {
+				logger.Error("task lock could not be released", taskLogAttrs(id, name, slog.Any("error", err))...)
+			}
+		})
+	}
+
+	return func() {
+		if remaining := atLeast - time.Since(acquiredTime); remaining > 0 {
+			time.AfterFunc(remaining, release)
+			return
+		}
+
+		release()
+	}, nil
+}
+
+// MemoryLocker is a Locker keeping the locks in memory, which can be used for the tasks running
+// in the same process or in tests.
+type MemoryLocker struct {
+	locks   map[string]*memoryLock
+	locksMu sync.Mutex
+}
+
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{
+		locks: make(map[string]*memoryLock),
+	}
+}
+
+func (locker *MemoryLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	locker.locksMu.Lock()
+	defer locker.locksMu.Unlock()
+
+	if lock, ok := locker.locks[key]; ok && time.Now().Before(lock.expiresAt) {
+		return nil, ErrLockNotAcquired
+	}
+
+	lock := &memoryLock{
+		locker:    locker,
+		key:       key,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	locker.locks[key] = lock
+	return lock, nil
+}
+
+// IsLocked returns whether the lock with the given key is held.
+func (locker *MemoryLocker) IsLocked(key string) bool {
+	locker.locksMu.Lock()
+	defer locker.locksMu.Unlock()
+
+	lock, ok := locker.locks[key]
+	return ok && time.Now().Before(lock.expiresAt)
+}
+
+type memoryLock struct {
+	locker    *MemoryLocker
+	key       string
+	expiresAt time.Time
+}
+
+func (lock *memoryLock) Extend(ctx context.Context, ttl time.Duration) error {
+	lock.locker.locksMu.Lock()
+	defer lock.locker.locksMu.Unlock()
+
+	if lock.locker.locks[lock.key] != lock {
+		return errors.New("lock is not held anymore")
+	}
+
+	lock.expiresAt = time.Now().Add(ttl)
+	return nil
+}
+
+func (lock *memoryLock) Release(ctx context.Context) error {
+	lock.locker.locksMu.Lock()
+	defer lock.locker.locksMu.Unlock()
+
+	if lock.locker.locks[lock.key] == lock {
+		delete(lock.locker.locks, lock.key)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package chrono
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// FileLocker is a Locker using the file locks of the operating system, which can be used for the
+// processes running on the same host. The locks are released by the operating system if a process exits,
+// so their ttl is not taken into account.
+type FileLocker struct {
+	dir string
+}
+
+func NewFileLocker(dir string) (*FileLocker, error) {
+	if dir == "" {
+		return nil, errors.New("dir cannot be empty")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileLocker{
+		dir: dir,
+	}, nil
+}
+
+func (locker *FileLocker) path(key string) string {
+	return filepath.Join(locker.dir, url.PathEscape(key)+".lock")
+}
@@ -0,0 +1,13 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package chrono
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func (locker *FileLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	return nil, errors.New("file locks are not supported on this platform")
+}
@@ -0,0 +1,51 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package chrono
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+func (locker *FileLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	file, err := os.OpenFile(locker.path(key), os.O_CREATE|os.O_RDWR, 0o644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, ErrLockNotAcquired
+		}
+
+		return nil, err
+	}
+
+	return &fileLock{
+		file: file,
+	}, nil
+}
+
+type fileLock struct {
+	file *os.File
+}
+
+func (lock *fileLock) Extend(ctx context.Context, ttl time.Duration) error {
+	return nil
+}
+
+func (lock *fileLock) Release(ctx context.Context) error {
+	err := syscall.Flock(int(lock.file.Fd()), syscall.LOCK_UN)
+
+	if closeErr := lock.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,166 @@
+package chrono
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const DefaultSQLLockTableName = "chrono_locks"
+
+type SQLLockerOption func(locker *SQLLocker) error
+
+// WithSQLLockTableName sets the name of the table the locks are kept in.
+func WithSQLLockTableName(tableName string) SQLLockerOption {
+	return func(locker *SQLLocker) error {
+		if !sqlIdentifierRegexp.MatchString(tableName) {
+			return fmt.Errorf("invalid table name %s", tableName)
+		}
+
+		locker.tableName = tableName
+		return nil
+	}
+}
+
+// WithSQLLockDollarPlaceholders makes the locker use $1, $2... placeholders instead of ?,
+// which is required by databases such as PostgreSQL.
+func WithSQLLockDollarPlaceholders() SQLLockerOption {
+	return func(locker *SQLLocker) error {
+		locker.dollarPlaceholders = true
+		return nil
+	}
+}
+
+// SQLLocker is a Locker keeping the locks as the rows of a database table, which can be shared by
+// the instances running on different hosts. The expiration times of the locks are calculated by
+// the instances, so their clocks are supposed to be synchronized.
+// The table can be created by calling CreateTable.
+type SQLLocker struct {
+	db                 *sql.DB
+	tableName          string
+	dollarPlaceholders bool
+}
+
+func NewSQLLocker(db *sql.DB, options ...SQLLockerOption) (*SQLLocker, error) {
+	if db == nil {
+		return nil, errors.New("db cannot be nil")
+	}
+
+	locker := &SQLLocker{
+		db:        db,
+		tableName: DefaultSQLLockTableName,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err := option(locker); err != nil {
+			return nil, err
+		}
+	}
+
+	return locker, nil
+}
+
+// CreateTable creates the table of the locks if it does not exist.
+func (locker *SQLLocker) CreateTable() error {
+	_, err := locker.db.Exec(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (name VARCHAR(255) PRIMARY KEY, owner VARCHAR(64) NOT NULL, locked_until BIGINT NOT NULL)",
+		locker.tableName,
+	))
+	return err
+}
+
+func (locker *SQLLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	owner, err := newLockOwner()
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lockedUntil := now.Add(ttl).UnixMilli()
+
+	// the expired lock is taken over if there is one
+	result, err := locker.db.ExecContext(ctx,
+		locker.query("UPDATE %s SET owner = ?, locked_until = ? WHERE name = ? AND locked_until <= ?"),
+		owner, lockedUntil, key, now.UnixMilli())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		_, err = locker.db.ExecContext(ctx,
+			locker.query("INSERT INTO %s (name, owner, locked_until) VALUES (?, ?, ?)"), key, owner, lockedUntil)
+
+		if err != nil {
+			var count int
+
+			if countErr := locker.db.QueryRowContext(ctx,
+				locker.query("SELECT COUNT(*) FROM %s WHERE name = ?"), key).Scan(&count); countErr == nil && count != 0 {
+				return nil, ErrLockNotAcquired
+			}
+
+			return nil, err
+		}
+	}
+
+	return &sqlLock{
+		locker: locker,
+		key:    key,
+		owner:  owner,
+	}, nil
+}
+
+func (locker *SQLLocker) query(format string) string {
+	return formatSQLQuery(format, locker.tableName, locker.dollarPlaceholders)
+}
+
+func newLockOwner() (string, error) {
+	owner := make([]byte, 16)
+
+	if _, err := rand.Read(owner); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(owner), nil
+}
+
+type sqlLock struct {
+	locker *SQLLocker
+	key    string
+	owner  string
+}
+
+func (lock *sqlLock) Extend(ctx context.Context, ttl time.Duration) error {
+	result, err := lock.locker.db.ExecContext(ctx,
+		lock.locker.query("UPDATE %s SET locked_until = ? WHERE name = ? AND owner = ?"),
+		time.Now().Add(ttl).UnixMilli(), lock.key, lock.owner)
+
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return errors.New("lock is not held anymore")
+	}
+
+	return nil
+}
+
+func (lock *sqlLock) Release(ctx context.Context) error {
+	_, err := lock.locker.db.ExecContext(ctx,
+		lock.locker.query("DELETE FROM %s WHERE name = ? AND owner = ?"), lock.key, lock.owner)
+	return err
+}
@@ -0,0 +1,277 @@
+package chrono
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingLocker struct {
+	Locker
+	extensions int32
+}
+
+func (locker *recordingLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	lock, err := locker.Locker.Acquire(ctx, key, ttl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingLock{Lock: lock, locker: locker}, nil
+}
+
+type recordingLock struct {
+	Lock
+	locker *recordingLocker
+}
+
+func (lock *recordingLock) Extend(ctx context.Context, ttl time.Duration) error {
+	atomic.AddInt32(&lock.locker.extensions, 1)
+	return lock.Lock.Extend(ctx, ttl)
+}
+
+func testLocker(t *testing.T, locker Locker) {
+	ctx := context.Background()
+
+	lock, err := locker.Acquire(ctx, "task", time.Minute)
+	assert.Nil(t, err)
+	assert.NotNil(t, lock)
+
+	_, err = locker.Acquire(ctx, "task", time.Minute)
+	assert.ErrorIs(t, err, ErrLockNotAcquired)
+
+	otherLock, err := locker.Acquire(ctx, "other-task", time.Minute)
+	assert.Nil(t, err)
+	assert.Nil(t, otherLock.Release(ctx))
+
+	assert.Nil(t, lock.Extend(ctx, time.Minute))
+	assert.Nil(t, lock.Release(ctx))
+
+	lock, err = locker.Acquire(ctx, "task", time.Minute)
+	assert.Nil(t, err)
+	assert.Nil(t, lock.Release(ctx))
+}
+
+func TestMemoryLocker(t *testing.T) {
+	testLocker(t, NewMemoryLocker())
+}
+
+func TestMemoryLocker_ExpiredLock(t *testing.T) {
+	locker := NewMemoryLocker()
+	ctx := context.Background()
+
+	lock, err := locker.Acquire(ctx, "task", 100*time.Millisecond)
+	assert.Nil(t, err)
+	assert.True(t, locker.IsLocked("task"))
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.False(t, locker.IsLocked("task"))
+
+	newLock, err := locker.Acquire(ctx, "task", time.Minute)
+	assert.Nil(t, err)
+
+	assert.NotNil(t, lock.Extend(ctx, time.Minute))
+	assert.Nil(t, lock.Release(ctx))
+	assert.True(t, locker.IsLocked("task"))
+
+	assert.Nil(t, newLock.Release(ctx))
+	assert.False(t, locker.IsLocked("task"))
+}
+
+func TestFileLocker(t *testing.T) {
+	locker, err := NewFileLocker(filepath.Join(t.TempDir(), "locks"))
+	assert.Nil(t, err)
+
+	testLocker(t, locker)
+}
+
+func TestNewFileLocker_WithEmptyDir(t *testing.T) {
+	locker, err := NewFileLocker("")
+	assert.Nil(t, locker)
+	assert.EqualError(t, err, "dir cannot be empty")
+}
+
+func TestNewSQLLocker_WithInvalidTableName(t *testing.T) {
+	locker, err := NewSQLLocker(&sql.DB{}, WithSQLLockTableName("locks;"))
+	assert.Nil(t, locker)
+	assert.EqualError(t, err, "invalid table name locks;")
+}
+
+func TestSimpleTaskExecutor_WithLocker(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	defer executor.Shutdown()
+
+	locker := NewMemoryLocker()
+	executor.SetLocker(locker)
+
+	otherLock, err := locker.Acquire(context.Background(), "locked-task", time.Minute)
+	assert.Nil(t, err)
+
+	var counter int32
+	listener := newRecordingTaskListener()
+	executor.AddListener(listener)
+
+	_, err = executor.schedule(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 0, 0, false, WithName("locked-task"))
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counter))
+	assert.Len(t, listener.get("OnSkip"), 1)
+	assert.ErrorIs(t, listener.get("OnSkip")[0].Err, ErrLockNotAcquired)
+	assert.Empty(t, listener.get("OnError"))
+
+	assert.Nil(t, otherLock.Release(context.Background()))
+
+	_, err = executor.schedule(func(ctx context.Context) {
+		assert.True(t, locker.IsLocked("locked-task"))
+		atomic.AddInt32(&counter, 1)
+	}, 0, 0, false, WithName("locked-task"))
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+	assert.False(t, locker.IsLocked("locked-task"))
+}
+
+func TestSimpleTaskExecutor_WithLockerExtendsLock(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	defer executor.Shutdown()
+
+	locker := &recordingLocker{Locker: NewMemoryLocker()}
+	executor.SetLocker(locker)
+
+	_, err := executor.schedule(func(ctx context.Context) {
+		<-time.After(500 * time.Millisecond)
+	}, 0, 0, false, WithName("task"), WithLockTTL(200*time.Millisecond))
+	assert.Nil(t, err)
+
+	<-time.After(700 * time.Millisecond)
+
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&locker.extensions), int32(2))
+}
+
+func TestSimpleTaskExecutor_WithLockerHoldsLockAtLeast(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	defer executor.Shutdown()
+
+	locker := NewMemoryLocker()
+	executor.SetLocker(locker)
+
+	_, err := executor.schedule(func(ctx context.Context) {}, 0, 0, false, WithName("task"), WithLockAtLeast(500*time.Millisecond))
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+	assert.True(t, locker.IsLocked("task"))
+
+	<-time.After(500 * time.Millisecond)
+	assert.False(t, locker.IsLocked("task"))
+}
+
+func TestSimpleTaskScheduler_WithLocker(t *testing.T) {
+	locker := NewMemoryLocker()
+
+	var (
+		counter   int32
+		instances []*SimpleTaskScheduler
+	)
+
+	for i := 0; i < 3; i++ {
+		scheduler := NewSimpleTaskScheduler(nil)
+		scheduler.SetLocker(locker)
+
+		_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+			atomic.AddInt32(&counter, 1)
+		}, "* * * * * *", WithName("cron-task"), WithLockAtLeast(500*time.Millisecond))
+		assert.Nil(t, err)
+
+		instances = append(instances, scheduler)
+	}
+
+	<-time.After(2500 * time.Millisecond)
+
+	for _, scheduler := range instances {
+		<-scheduler.Shutdown()
+	}
+
+	runs := atomic.LoadInt32(&counter)
+	assert.True(t, runs >= 2 && runs <= 3, "the cron task must run once per second, but it run %d times", runs)
+
+	skips := 0
+
+	for _, scheduler := range instances {
+		history, err := scheduler.History("cron-task")
+		assert.Nil(t, err)
+
+		for _, record := range history {
+			if record.Outcome == ExecutionSkipped {
+				assert.ErrorIs(t, record.Err, ErrLockNotAcquired)
+				skips++
+			}
+		}
+	}
+
+	assert.Equal(t, int(runs)*2, skips)
+}
+
+func TestSimpleTaskExecutor_WithLockerHoldsLockOfPeriodicTaskByDefault(t *testing.T) {
+	locker := NewMemoryLocker()
+
+	var (
+		counter   int32
+		executors []*SimpleTaskExecutor
+	)
+
+	for i := 0; i < 3; i++ {
+		executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+		executor.SetLocker(locker)
+
+		_, err := executor.schedule(func(ctx context.Context) {
+			atomic.AddInt32(&counter, 1)
+		}, 0, 200*time.Millisecond, true, WithName("fast-task"))
+		assert.Nil(t, err)
+
+		executors = append(executors, executor)
+
+		// the instances fire the same executions a bit later than each other
+		<-time.After(20 * time.Millisecond)
+	}
+
+	<-time.After(900 * time.Millisecond)
+
+	for _, executor := range executors {
+		<-executor.Shutdown()
+	}
+
+	runs := atomic.LoadInt32(&counter)
+	assert.True(t, runs >= 4 && runs <= 6, "the task must run once per period, but it run %d times", runs)
+}
+
+func TestScheduledTask_TriggerNowWithLockOptions(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	defer executor.Shutdown()
+
+	locker := NewMemoryLocker()
+	executor.SetLocker(locker)
+
+	task, err := executor.schedule(func(ctx context.Context) {}, time.Hour, time.Hour, true,
+		WithName("task"), WithLockAtLeast(300*time.Millisecond))
+	assert.Nil(t, err)
+
+	assert.Nil(t, task.(*ScheduledRunnableTask).TriggerNow())
+
+	<-time.After(100 * time.Millisecond)
+	assert.True(t, locker.IsLocked("task"))
+
+	<-time.After(400 * time.Millisecond)
+	assert.False(t, locker.IsLocked("task"))
+}
@@ -0,0 +1,67 @@
+package chrono
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+type discardHandler struct {
+}
+
+func (handler discardHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return false
+}
+
+func (handler discardHandler) Handle(ctx context.Context, record slog.Record) error {
+	return nil
+}
+
+func (handler discardHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return handler
+}
+
+func (handler discardHandler) WithGroup(name string) slog.Handler {
+	return handler
+}
+
+var noopLogger = slog.New(discardHandler{})
+
+// loggerHolder keeps a logger which can be replaced while it is being used by other goroutines.
+type loggerHolder struct {
+	value atomic.Value
+}
+
+func (holder *loggerHolder) set(logger *slog.Logger) {
+	if logger == nil {
+		logger = noopLogger
+	}
+
+	holder.value.Store(logger)
+}
+
+func (holder *loggerHolder) get() *slog.Logger {
+	logger, ok := holder.value.Load().(*slog.Logger)
+
+	if !ok {
+		return noopLogger
+	}
+
+	return logger
+}
+
+type loggerProvider interface {
+	logger() *slog.Logger
+}
+
+func loggerOf(executor TaskExecutor) *slog.Logger {
+	if provider, ok := executor.(loggerProvider); ok {
+		return provider.logger()
+	}
+
+	return noopLogger
+}
+
+func taskLogAttrs(id int, name string, attrs ...any) []any {
+	return append([]any{slog.Int("task_id", id), slog.String("task_name", name)}, attrs...)
+}
@@ -0,0 +1,97 @@
+package chrono
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type syncBuffer struct {
+	buffer   bytes.Buffer
+	bufferMu sync.Mutex
+}
+
+func (buffer *syncBuffer) Write(p []byte) (int, error) {
+	buffer.bufferMu.Lock()
+	defer buffer.bufferMu.Unlock()
+	return buffer.buffer.Write(p)
+}
+
+func (buffer *syncBuffer) String() string {
+	buffer.bufferMu.Lock()
+	defer buffer.bufferMu.Unlock()
+	return buffer.buffer.String()
+}
+
+func newTestLogger(level slog.Level) (*slog.Logger, *syncBuffer) {
+	buffer := &syncBuffer{}
+	return slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: level})), buffer
+}
+
+func TestSimpleTaskExecutor_SetLogger(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	logger, buffer := newTestLogger(slog.LevelDebug)
+	executor.SetLogger(logger)
+
+	_, err := executor.schedule(func(ctx context.Context) {
+		execution, _ := ExecutionFromContext(ctx)
+		execution.Fail(errors.New("test error"))
+	}, 0, 0, false, WithName("failing-task"))
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	output := buffer.String()
+
+	assert.Contains(t, output, `msg="task scheduled" task_id=1 task_name=failing-task`)
+	assert.Contains(t, output, `msg="task started" task_id=1 task_name=failing-task`)
+	assert.Contains(t, output, `msg="task failed" task_id=1 task_name=failing-task`)
+	assert.Contains(t, output, `error="test error"`)
+}
+
+func TestSimpleTaskExecutor_SetLogger_Level(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	logger, buffer := newTestLogger(slog.LevelWarn)
+	executor.SetLogger(logger)
+
+	_, err := executor.Schedule(func(ctx context.Context) {
+	}, 0)
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	assert.Empty(t, buffer.String())
+}
+
+func TestSimpleTaskExecutor_WithoutLogger(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	executor.SetLogger(nil)
+
+	assert.False(t, executor.logger().Enabled(context.Background(), slog.LevelError))
+}
+
+func TestSimpleTaskScheduler_SetLogger(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	scheduler := NewSimpleTaskScheduler(executor)
+	logger, buffer := newTestLogger(slog.LevelDebug)
+	scheduler.SetLogger(logger)
+
+	_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		executor.Shutdown()
+	}, "* * * * * *", WithName("cron-task"))
+	assert.Nil(t, err)
+
+	_, err = scheduler.ScheduleWithCron(func(ctx context.Context) {
+	}, "* * * * * *", WithName("cron-task"))
+	assert.Error(t, err)
+
+	<-time.After(1*time.Second + 100*time.Millisecond)
+	output := buffer.String()
+
+	assert.Contains(t, output, `msg="task registered" task_id=1 task_name=cron-task`)
+	assert.Contains(t, output, `msg="task rejected because a task with the same name is already scheduled"`)
+	assert.Contains(t, output, `msg="task stopped because its next execution could not be scheduled" task_id=1 task_name=cron-task`)
+}
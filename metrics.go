@@ -0,0 +1,311 @@
+package chrono
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics is the interface to plug a metrics backend into the executor. The methods
+// are called from the goroutine dispatching the tasks, so they must not block.
+type Metrics interface {
+	IncTaskRuns(taskName string)
+	IncTaskFailures(taskName string)
+	IncTaskPanics(taskName string)
+	IncTaskSkips(taskName string)
+	IncTaskMisfires(taskName string)
+	ObserveTaskDuration(taskName string, duration time.Duration)
+	ObserveTaskStartLateness(taskName string, lateness time.Duration)
+	AddRunningTasks(taskName string, delta int)
+	SetQueueLength(length int)
+}
+
+type noopMetrics struct {
+}
+
+func (metrics noopMetrics) IncTaskRuns(taskName string) {
+}
+
+func (metrics noopMetrics) IncTaskFailures(taskName string) {
+}
+
+func (metrics noopMetrics) IncTaskPanics(taskName string) {
+}
+
+func (metrics noopMetrics) IncTaskSkips(taskName string) {
+}
+
+func (metrics noopMetrics) IncTaskMisfires(taskName string) {
+}
+
+func (metrics noopMetrics) ObserveTaskDuration(taskName string, duration time.Duration) {
+}
+
+func (metrics noopMetrics) ObserveTaskStartLateness(taskName string, lateness time.Duration) {
+}
+
+func (metrics noopMetrics) AddRunningTasks(taskName string, delta int) {
+}
+
+func (metrics noopMetrics) SetQueueLength(length int) {
+}
+
+type metricsHolder struct {
+	value atomic.Value
+}
+
+type storedMetrics struct {
+	metrics Metrics
+}
+
+func (holder *metricsHolder) set(metrics Metrics) {
+	if metrics == nil {
+		metrics = noopMetrics{}
+	}
+
+	holder.value.Store(storedMetrics{metrics})
+}
+
+func (holder *metricsHolder) get() Metrics {
+	stored, ok := holder.value.Load().(storedMetrics)
+
+	if !ok {
+		return noopMetrics{}
+	}
+
+	return stored.metrics
+}
+
+var DefaultHistogramBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// PrometheusMetrics keeps the metrics in memory and exposes them in the Prometheus text format.
+type PrometheusMetrics struct {
+	buckets      []float64
+	counters     map[string]map[string]float64
+	histograms   map[string]map[string]*histogram
+	runningTasks map[string]float64
+	queueLength  float64
+	metricsMu    sync.Mutex
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+const (
+	metricTaskRuns          = "chrono_task_runs_total"
+	metricTaskFailures      = "chrono_task_failures_total"
+	metricTaskPanics        = "chrono_task_panics_total"
+	metricTaskSkips         = "chrono_task_skips_total"
+	metricTaskMisfires      = "chrono_task_misfires_total"
+	metricTaskDuration      = "chrono_task_duration_seconds"
+	metricTaskStartLateness = "chrono_task_start_lateness_seconds"
+	metricTasksRunning      = "chrono_tasks_running"
+	metricQueueLength       = "chrono_executor_queue_length"
+)
+
+var metricHelps = map[string]string{
+	metricTaskRuns:          "Total number of task executions.",
+	metricTaskFailures:      "Total number of failed task executions.",
+	metricTaskPanics:        "Total number of task executions ended with a panic.",
+	metricTaskSkips:         "Total number of skipped task executions.",
+	metricTaskMisfires:      "Total number of missed task executions.",
+	metricTaskDuration:      "Duration of task executions in seconds.",
+	metricTaskStartLateness: "Delay between the scheduled and the actual start time of task executions in seconds.",
+	metricTasksRunning:      "Number of running task executions.",
+	metricQueueLength:       "Number of tasks waiting in the executor queue.",
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return NewPrometheusMetricsWithBuckets(DefaultHistogramBuckets)
+}
+
+func NewPrometheusMetricsWithBuckets(buckets []float64) *PrometheusMetrics {
+	sortedBuckets := append([]float64{}, buckets...)
+	sort.Float64s(sortedBuckets)
+
+	return &PrometheusMetrics{
+		buckets:      sortedBuckets,
+		counters:     make(map[string]map[string]float64),
+		histograms:   make(map[string]map[string]*histogram),
+		runningTasks: make(map[string]float64),
+	}
+}
+
+func (metrics *PrometheusMetrics) IncTaskRuns(taskName string) {
+	metrics.incCounter(metricTaskRuns, taskName)
+}
+
+func (metrics *PrometheusMetrics) IncTaskFailures(taskName string) {
+	metrics.incCounter(metricTaskFailures, taskName)
+}
+
+func (metrics *PrometheusMetrics) IncTaskPanics(taskName string) {
+	metrics.incCounter(metricTaskPanics, taskName)
+}
+
+func (metrics *PrometheusMetrics) IncTaskSkips(taskName string) {
+	metrics.incCounter(metricTaskSkips, taskName)
+}
+
+func (metrics *PrometheusMetrics) IncTaskMisfires(taskName string) {
+	metrics.incCounter(metricTaskMisfires, taskName)
+}
+
+func (metrics *PrometheusMetrics) ObserveTaskDuration(taskName string, duration time.Duration) {
+	metrics.observe(metricTaskDuration, taskName, duration.Seconds())
+}
+
+func (metrics *PrometheusMetrics) ObserveTaskStartLateness(taskName string, lateness time.Duration) {
+	if lateness < 0 {
+		lateness = 0
+	}
+
+	metrics.observe(metricTaskStartLateness, taskName, lateness.Seconds())
+}
+
+func (metrics *PrometheusMetrics) AddRunningTasks(taskName string, delta int) {
+	metrics.metricsMu.Lock()
+	defer metrics.metricsMu.Unlock()
+	metrics.runningTasks[taskName] += float64(delta)
+}
+
+func (metrics *PrometheusMetrics) SetQueueLength(length int) {
+	metrics.metricsMu.Lock()
+	defer metrics.metricsMu.Unlock()
+	metrics.queueLength = float64(length)
+}
+
+func (metrics *PrometheusMetrics) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.WriteTo(writer)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (metrics *PrometheusMetrics) WriteTo(writer io.Writer) (int64, error) {
+	metrics.metricsMu.Lock()
+	defer metrics.metricsMu.Unlock()
+
+	builder := &strings.Builder{}
+
+	for _, name := range []string{metricTaskRuns, metricTaskFailures, metricTaskPanics, metricTaskSkips, metricTaskMisfires} {
+		writeMetricHeader(builder, name, "counter")
+
+		for _, taskName := range sortedKeys(metrics.counters[name]) {
+			writeSample(builder, name, taskLabel(taskName), metrics.counters[name][taskName])
+		}
+	}
+
+	for _, name := range []string{metricTaskDuration, metricTaskStartLateness} {
+		writeMetricHeader(builder, name, "histogram")
+
+		for _, taskName := range sortedKeys(metrics.histograms[name]) {
+			histogram := metrics.histograms[name][taskName]
+
+			for index, bucket := range metrics.buckets {
+				labels := taskLabel(taskName) + `,le="` + formatFloat(bucket) + `"`
+				writeSample(builder, name+"_bucket", labels, float64(histogram.counts[index]))
+			}
+
+			writeSample(builder, name+"_bucket", taskLabel(taskName)+`,le="+Inf"`, float64(histogram.count))
+			writeSample(builder, name+"_sum", taskLabel(taskName), histogram.sum)
+			writeSample(builder, name+"_count", taskLabel(taskName), float64(histogram.count))
+		}
+	}
+
+	writeMetricHeader(builder, metricTasksRunning, "gauge")
+
+	for _, taskName := range sortedKeys(metrics.runningTasks) {
+		writeSample(builder, metricTasksRunning, taskLabel(taskName), metrics.runningTasks[taskName])
+	}
+
+	writeMetricHeader(builder, metricQueueLength, "gauge")
+	writeSample(builder, metricQueueLength, "", metrics.queueLength)
+
+	written, err := io.WriteString(writer, builder.String())
+	return int64(written), err
+}
+
+func (metrics *PrometheusMetrics) incCounter(name string, taskName string) {
+	metrics.metricsMu.Lock()
+	defer metrics.metricsMu.Unlock()
+
+	if metrics.counters[name] == nil {
+		metrics.counters[name] = make(map[string]float64)
+	}
+
+	metrics.counters[name][taskName]++
+}
+
+func (metrics *PrometheusMetrics) observe(name string, taskName string, value float64) {
+	metrics.metricsMu.Lock()
+	defer metrics.metricsMu.Unlock()
+
+	if metrics.histograms[name] == nil {
+		metrics.histograms[name] = make(map[string]*histogram)
+	}
+
+	taskHistogram, ok := metrics.histograms[name][taskName]
+
+	if !ok {
+		taskHistogram = &histogram{
+			counts: make([]uint64, len(metrics.buckets)),
+		}
+		metrics.histograms[name][taskName] = taskHistogram
+	}
+
+	for index, bucket := range metrics.buckets {
+		if value <= bucket {
+			taskHistogram.counts[index]++
+		}
+	}
+
+	taskHistogram.count++
+	taskHistogram.sum += value
+}
+
+func writeMetricHeader(builder *strings.Builder, name string, metricType string) {
+	fmt.Fprintf(builder, "# HELP %s %s\n# TYPE %s %s\n", name, metricHelps[name], name, metricType)
+}
+
+func writeSample(builder *strings.Builder, name string, labels string, value float64) {
+	if labels == "" {
+		fmt.Fprintf(builder, "%s %s\n", name, formatFloat(value))
+		return
+	}
+
+	fmt.Fprintf(builder, "%s{%s} %s\n", name, labels, formatFloat(value))
+}
+
+func taskLabel(taskName string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `task="` + replacer.Replace(taskName) + `"`
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,112 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics_WriteTo(t *testing.T) {
+	metrics := NewPrometheusMetricsWithBuckets([]float64{0.1, 1})
+	metrics.IncTaskRuns("task")
+	metrics.IncTaskRuns("task")
+	metrics.IncTaskFailures(`quoted "task"`)
+	metrics.ObserveTaskDuration("task", 500*time.Millisecond)
+	metrics.AddRunningTasks("task", 1)
+	metrics.SetQueueLength(3)
+
+	builder := &strings.Builder{}
+	_, err := metrics.WriteTo(builder)
+	assert.Nil(t, err)
+	output := builder.String()
+
+	assert.Contains(t, output, "# TYPE chrono_task_runs_total counter\n")
+	assert.Contains(t, output, `chrono_task_runs_total{task="task"} 2`+"\n")
+	assert.Contains(t, output, `chrono_task_failures_total{task="quoted \"task\""} 1`+"\n")
+	assert.Contains(t, output, "# TYPE chrono_task_duration_seconds histogram\n")
+	assert.Contains(t, output, `chrono_task_duration_seconds_bucket{task="task",le="0.1"} 0`+"\n")
+	assert.Contains(t, output, `chrono_task_duration_seconds_bucket{task="task",le="1"} 1`+"\n")
+	assert.Contains(t, output, `chrono_task_duration_seconds_bucket{task="task",le="+Inf"} 1`+"\n")
+	assert.Contains(t, output, `chrono_task_duration_seconds_sum{task="task"} 0.5`+"\n")
+	assert.Contains(t, output, `chrono_task_duration_seconds_count{task="task"} 1`+"\n")
+	assert.Contains(t, output, `chrono_tasks_running{task="task"} 1`+"\n")
+	assert.Contains(t, output, "chrono_executor_queue_length 3\n")
+}
+
+func TestPrometheusMetrics_ServeHTTP(t *testing.T) {
+	metrics := NewPrometheusMetrics()
+	metrics.IncTaskSkips("task")
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	response := recorder.Result()
+	body, _ := io.ReadAll(response.Body)
+
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", response.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), `chrono_task_skips_total{task="task"} 1`)
+}
+
+func TestSimpleTaskExecutor_SetMetrics(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	metrics := NewPrometheusMetrics()
+	executor.SetMetrics(metrics)
+
+	_, err := executor.schedule(func(ctx context.Context) {
+		execution, _ := ExecutionFromContext(ctx)
+		execution.Fail(errors.New("test error"))
+	}, 0, 0, false, WithName("failing-task"))
+	assert.Nil(t, err)
+
+	_, err = executor.schedule(func(ctx context.Context) {
+		panic("test panic")
+	}, 0, 0, false, WithName("panicking-task"))
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+
+	builder := &strings.Builder{}
+	_, err = metrics.WriteTo(builder)
+	assert.Nil(t, err)
+	output := builder.String()
+
+	assert.Contains(t, output, `chrono_task_runs_total{task="failing-task"} 1`)
+	assert.Contains(t, output, `chrono_task_failures_total{task="failing-task"} 1`)
+	assert.NotContains(t, output, `chrono_task_panics_total{task="failing-task"}`)
+	assert.Contains(t, output, `chrono_task_runs_total{task="panicking-task"} 1`)
+	assert.Contains(t, output, `chrono_task_panics_total{task="panicking-task"} 1`)
+	assert.Contains(t, output, `chrono_task_start_lateness_seconds_count{task="failing-task"} 1`)
+	assert.Contains(t, output, `chrono_tasks_running{task="failing-task"} 0`)
+	assert.Contains(t, output, "chrono_executor_queue_length 0")
+}
+
+func TestSimpleTaskScheduler_SetMetrics(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewSimpleTaskExecutor(NewDefaultTaskRunner()))
+	metrics := NewPrometheusMetrics()
+	scheduler.SetMetrics(metrics)
+
+	task, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {
+	}, 200*time.Millisecond, WithName("paused-task"))
+	assert.Nil(t, err)
+
+	<-time.After(50 * time.Millisecond)
+	task.(Pausable).Pause()
+	<-time.After(400 * time.Millisecond)
+	task.(Pausable).Resume()
+	<-time.After(50 * time.Millisecond)
+	task.Cancel()
+
+	builder := &strings.Builder{}
+	_, err = metrics.WriteTo(builder)
+	assert.Nil(t, err)
+	output := builder.String()
+
+	assert.Contains(t, output, `chrono_task_misfires_total{task="paused-task"} 1`)
+	assert.Contains(t, output, `chrono_task_duration_seconds_count{task="paused-task"}`)
+}
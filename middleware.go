@@ -0,0 +1,119 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Middleware wraps a task to run some logic around its executions. The metadata of the
+// current execution such as task id, task name and scheduled time is accessible through
+// ExecutionFromContext.
+type Middleware func(next Task) Task
+
+// ChainMiddlewares combines the given middlewares into a single one. The first middleware
+// is the outermost one and the last middleware is the closest one to the task.
+func ChainMiddlewares(middlewares ...Middleware) Middleware {
+	return func(next Task) Task {
+		for index := len(middlewares) - 1; index >= 0; index-- {
+			next = middlewares[index](next)
+		}
+
+		return next
+	}
+}
+
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(task *SchedulerTask) error {
+		for _, middleware := range middlewares {
+			if middleware == nil {
+				return errors.New("middleware cannot be nil")
+			}
+		}
+
+		task.middlewares = append(task.middlewares, middlewares...)
+		return nil
+	}
+}
+
+// WithTimeout cancels the context of each execution of a task after the given duration. An execution
+// still running when the context is cancelled is marked as failed with ErrExecutionTimeout once it returns,
+// which is also the cause of the cancellation returned by context.Cause.
+func WithTimeout(timeout time.Duration) Option {
+	return func(task *SchedulerTask) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be greater than zero")
+		}
+
+		task.timeout = timeout
+		return nil
+	}
+}
+
+// WithRetry runs a failed execution of a task again after the given delay until it succeeds or
+// it is attempted as many times as the given max attempts.
+func WithRetry(maxAttempts int, delay time.Duration) Option {
+	return func(task *SchedulerTask) error {
+		if maxAttempts < 1 {
+			return errors.New("max attempts must be at least one")
+		}
+
+		if delay < 0 {
+			return errors.New("retry delay cannot be negative")
+		}
+
+		task.retryAttempts = maxAttempts
+		task.retryDelay = delay
+		return nil
+	}
+}
+
+func timeoutMiddleware(timeout time.Duration) Middleware {
+	return func(next Task) Task {
+		return func(ctx context.Context) {
+			timeoutCtx, cancel := context.WithTimeoutCause(ctx, timeout, ErrExecutionTimeout)
+			defer cancel()
+
+			next(timeoutCtx)
+
+			if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+				if execution, ok := ExecutionFromContext(ctx); ok {
+					execution.Fail(ErrExecutionTimeout)
+				}
+			}
+		}
+	}
+}
+
+func retryMiddleware(maxAttempts int, delay time.Duration) Middleware {
+	return func(next Task) Task {
+		return func(ctx context.Context) {
+			execution, ok := ExecutionFromContext(ctx)
+
+			if !ok {
+				next(ctx)
+				return
+			}
+
+			for {
+				execution.Fail(runTask(ctx, next))
+
+				if execution.Err() == nil || execution.getSkipReason() != nil || execution.Attempt() >= maxAttempts {
+					return
+				}
+
+				// each attempt has its own span, so that the span of the failed attempt is ended before the delay
+				execution.endSpan()
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+
+				execution.retry()
+				ctx = execution.startSpan(ctx)
+			}
+		}
+	}
+}
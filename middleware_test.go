@@ -0,0 +1,207 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type middlewareRecorder struct {
+	calls   []string
+	callsMu sync.Mutex
+}
+
+func (recorder *middlewareRecorder) middleware(name string) Middleware {
+	return func(next Task) Task {
+		return func(ctx context.Context) {
+			recorder.record(name + ":before")
+			next(ctx)
+			recorder.record(name + ":after")
+		}
+	}
+}
+
+func (recorder *middlewareRecorder) record(call string) {
+	recorder.callsMu.Lock()
+	defer recorder.callsMu.Unlock()
+	recorder.calls = append(recorder.calls, call)
+}
+
+func (recorder *middlewareRecorder) get() []string {
+	recorder.callsMu.Lock()
+	defer recorder.callsMu.Unlock()
+	return append([]string{}, recorder.calls...)
+}
+
+func TestChainMiddlewares(t *testing.T) {
+	recorder := &middlewareRecorder{}
+
+	task := ChainMiddlewares(recorder.middleware("first"), recorder.middleware("second"))(func(ctx context.Context) {
+		recorder.record("task")
+	})
+
+	task(context.Background())
+
+	assert.Equal(t, []string{"first:before", "second:before", "task", "second:after", "first:after"}, recorder.get())
+}
+
+func TestWithMiddleware_NilMiddleware(t *testing.T) {
+	_, err := CreateSchedulerTask(func(ctx context.Context) {
+
+	}, WithMiddleware(nil))
+	assert.Error(t, err)
+}
+
+func TestSimpleTaskScheduler_Use(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schedule func(scheduler TaskScheduler, task Task, options ...Option) (ScheduledTask, error)
+	}{
+		{"Schedule", func(scheduler TaskScheduler, task Task, options ...Option) (ScheduledTask, error) {
+			return scheduler.Schedule(task, options...)
+		}},
+		{"ScheduleWithCron", func(scheduler TaskScheduler, task Task, options ...Option) (ScheduledTask, error) {
+			return scheduler.ScheduleWithCron(task, "* * * * * *", options...)
+		}},
+		{"ScheduleWithFixedDelay", func(scheduler TaskScheduler, task Task, options ...Option) (ScheduledTask, error) {
+			return scheduler.ScheduleWithFixedDelay(task, time.Hour, options...)
+		}},
+		{"ScheduleAtFixedRate", func(scheduler TaskScheduler, task Task, options ...Option) (ScheduledTask, error) {
+			return scheduler.ScheduleAtFixedRate(task, time.Hour, options...)
+		}},
+	}
+
+	for _, testCase := range testCases {
+		scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+		recorder := &middlewareRecorder{}
+		scheduler.Use(recorder.middleware("global"))
+
+		var taskName string
+		done := make(chan bool, 1)
+
+		task, err := testCase.schedule(scheduler, func(ctx context.Context) {
+			execution, _ := ExecutionFromContext(ctx)
+			taskName = execution.TaskName()
+			recorder.record("task")
+			done <- true
+		}, WithName(testCase.name), WithMiddleware(recorder.middleware("task-specific")))
+
+		assert.Nil(t, err)
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("task scheduled by %s has not been executed", testCase.name)
+		}
+
+		task.Cancel()
+		<-time.After(10 * time.Millisecond)
+
+		assert.Equal(t, testCase.name, taskName)
+		assert.Equal(t, []string{"global:before", "task-specific:before", "task", "task-specific:after", "global:after"},
+			recorder.get(), "middlewares applied by %s", testCase.name)
+	}
+}
+
+func TestWithTimeout_InvalidTimeout(t *testing.T) {
+	_, err := CreateSchedulerTask(func(ctx context.Context) {}, WithTimeout(0))
+	assert.EqualError(t, err, "timeout must be greater than zero")
+}
+
+func TestWithRetry_InvalidOptions(t *testing.T) {
+	_, err := CreateSchedulerTask(func(ctx context.Context) {}, WithRetry(0, time.Second))
+	assert.EqualError(t, err, "max attempts must be at least one")
+
+	_, err = CreateSchedulerTask(func(ctx context.Context) {}, WithRetry(3, -time.Second))
+	assert.EqualError(t, err, "retry delay cannot be negative")
+}
+
+func TestSimpleTaskScheduler_WithTimeout(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	cancelled := make(chan bool, 1)
+
+	_, err := scheduler.Schedule(func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+			cancelled <- errors.Is(context.Cause(ctx), ErrExecutionTimeout)
+		case <-time.After(time.Second):
+			cancelled <- false
+		}
+	}, WithName("task"), WithTimeout(100*time.Millisecond))
+	assert.Nil(t, err)
+
+	assert.True(t, <-cancelled)
+	<-time.After(50 * time.Millisecond)
+
+	history, err := scheduler.History("task")
+	assert.Nil(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, ExecutionFailed, history[0].Outcome)
+	assert.ErrorIs(t, history[0].Err, ErrExecutionTimeout)
+}
+
+func TestSimpleTaskScheduler_WithRetry(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	var attempts []int
+	var attemptsMu sync.Mutex
+
+	_, err := scheduler.Schedule(func(ctx context.Context) {
+		execution, _ := ExecutionFromContext(ctx)
+
+		attemptsMu.Lock()
+		attempts = append(attempts, execution.Attempt())
+		attemptsMu.Unlock()
+
+		if execution.Attempt() == 1 {
+			panic("first attempt failed")
+		} else if execution.Attempt() == 2 {
+			execution.Fail(errors.New("second attempt failed"))
+		}
+	}, WithName("task"), WithRetry(5, 50*time.Millisecond))
+	assert.Nil(t, err)
+
+	<-time.After(300 * time.Millisecond)
+
+	attemptsMu.Lock()
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	attemptsMu.Unlock()
+
+	history, err := scheduler.History("task")
+	assert.Nil(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, ExecutionSucceeded, history[0].Outcome)
+	assert.Equal(t, 3, history[0].Attempt)
+}
+
+func TestSimpleTaskScheduler_WithRetryAndTimeout(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	var counter int32
+
+	_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+		<-ctx.Done()
+	}, "0 0 0 1 1 *", WithName("task"), WithRetry(2, 0), WithTimeout(50*time.Millisecond))
+	assert.Nil(t, err)
+
+	assert.Nil(t, scheduler.TriggerNow("task"))
+	<-time.After(300 * time.Millisecond)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&counter))
+
+	history, err := scheduler.History("task")
+	assert.Nil(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, ExecutionFailed, history[0].Outcome)
+	assert.ErrorIs(t, history[0].Err, ErrExecutionTimeout)
+	assert.Equal(t, 2, history[0].Attempt)
+}
@@ -0,0 +1,90 @@
+package otelchrono
+
+import (
+	"codnect.io/chrono"
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "codnect.io/chrono/otelchrono"
+
+const (
+	TaskIDKey         = attribute.Key("chrono.task.id")
+	TaskNameKey       = attribute.Key("chrono.task.name")
+	TriggerTypeKey    = attribute.Key("chrono.trigger.type")
+	CronExpressionKey = attribute.Key("chrono.cron.expression")
+	ScheduledTimeKey  = attribute.Key("chrono.scheduled_time")
+	AttemptKey        = attribute.Key("chrono.attempt")
+)
+
+type Option func(tracer *Tracer)
+
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(tracer *Tracer) {
+		if provider != nil {
+			tracer.tracer = provider.Tracer(instrumentationName)
+		}
+	}
+}
+
+// Tracer creates an OpenTelemetry root span for each execution of a task.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+func NewTracer(options ...Option) *Tracer {
+	tracer := &Tracer{
+		tracer: otel.GetTracerProvider().Tracer(instrumentationName),
+	}
+
+	for _, option := range options {
+		option(tracer)
+	}
+
+	return tracer
+}
+
+func (tracer *Tracer) Start(ctx context.Context, info chrono.SpanInfo) (context.Context, chrono.Span) {
+	attributes := []attribute.KeyValue{
+		TaskIDKey.Int(info.TaskID),
+		TaskNameKey.String(info.TaskName),
+		TriggerTypeKey.String(info.TriggerType),
+		ScheduledTimeKey.String(info.ScheduledTime.Format("2006-01-02T15:04:05.000000000Z07:00")),
+		AttemptKey.Int(info.Attempt),
+	}
+
+	if info.CronExpression != "" {
+		attributes = append(attributes, CronExpressionKey.String(info.CronExpression))
+	}
+
+	ctx, span := tracer.tracer.Start(ctx, spanName(info),
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attributes...))
+
+	return ctx, &Span{span}
+}
+
+type Span struct {
+	span trace.Span
+}
+
+func (span *Span) RecordError(err error) {
+	span.span.RecordError(err)
+	span.span.SetStatus(codes.Error, err.Error())
+}
+
+func (span *Span) End() {
+	span.span.End()
+}
+
+func spanName(info chrono.SpanInfo) string {
+	if info.TaskName == "" {
+		return "chrono.task"
+	}
+
+	return "chrono.task " + info.TaskName
+}
@@ -0,0 +1,77 @@
+package otelchrono
+
+import (
+	"codnect.io/chrono"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+	"time"
+)
+
+func TestTracer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	scheduler := chrono.NewSimpleTaskScheduler(chrono.NewSimpleTaskExecutor(chrono.NewDefaultTaskRunner()))
+	scheduler.SetTracer(NewTracer(WithTracerProvider(provider)))
+
+	spanContexts := make(chan trace.SpanContext, 10)
+
+	task, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		spanContexts <- trace.SpanContextFromContext(ctx)
+		execution, _ := chrono.ExecutionFromContext(ctx)
+		execution.Fail(errors.New("test error"))
+	}, "* * * * * *", chrono.WithName("cron-task"))
+	assert.Nil(t, err)
+
+	<-time.After(1100 * time.Millisecond)
+	task.Cancel()
+
+	spans := exporter.GetSpans()
+	assert.GreaterOrEqual(t, len(spans), 1)
+
+	span := spans[0]
+	assert.Equal(t, "chrono.task cron-task", span.Name)
+	assert.False(t, span.Parent.IsValid())
+	assert.Equal(t, (<-spanContexts).TraceID(), span.SpanContext.TraceID())
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Equal(t, "test error", span.Status.Description)
+	assert.Len(t, span.Events, 1)
+
+	attributes := make(map[string]interface{})
+
+	for _, attribute := range span.Attributes {
+		attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+	}
+
+	assert.Equal(t, "cron-task", attributes["chrono.task.name"])
+	assert.Equal(t, chrono.TriggerTypeCron, attributes["chrono.trigger.type"])
+	assert.Equal(t, "* * * * * *", attributes["chrono.cron.expression"])
+	assert.Equal(t, int64(1), attributes["chrono.attempt"])
+	assert.NotEmpty(t, attributes["chrono.scheduled_time"])
+}
+
+func TestTracer_WithoutError(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	executor := chrono.NewSimpleTaskExecutor(chrono.NewDefaultTaskRunner())
+	executor.SetTracer(NewTracer(WithTracerProvider(provider)))
+
+	_, err := executor.Schedule(func(ctx context.Context) {
+	}, 0)
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "chrono.task", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Empty(t, spans[0].Events)
+}
@@ -0,0 +1,292 @@
+package chrono
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReconcileResult contains the names of the jobs changed by a reconciliation.
+type ReconcileResult struct {
+	Added       []string
+	Updated     []string
+	Rescheduled []string
+	Removed     []string
+}
+
+// JobReconciler keeps the jobs scheduled on a scheduler in sync with the job definitions given to it.
+// Only the jobs scheduled by the reconciler are managed by it, the other tasks of the scheduler are left as they are.
+type JobReconciler struct {
+	scheduler *SimpleTaskScheduler
+	jobs      map[string]Job
+	mu        sync.Mutex
+}
+
+func NewJobReconciler(scheduler *SimpleTaskScheduler) (*JobReconciler, error) {
+	if scheduler == nil {
+		return nil, errors.New("scheduler cannot be nil")
+	}
+
+	return &JobReconciler{
+		scheduler: scheduler,
+		jobs:      make(map[string]Job),
+	}, nil
+}
+
+// Reconcile diffs the enabled jobs in the given definitions against the jobs scheduled before. The jobs which
+// are not defined anymore are cancelled, and the new ones are scheduled. If only the schedule or the location of
+// a job is changed, its task is rescheduled in place, which keeps its trigger context and history. Otherwise, the
+// job is scheduled again with the id and history of its task, keeping the trigger context of a cron job. A one-shot
+// job which has already been run is not run again unless its start time is moved to a later time. The unchanged
+// jobs are not touched.
+//
+// The definitions which are invalid do not change the jobs scheduled with their names, and their errors are
+// returned altogether with the errors of the jobs which cannot be scheduled.
+func (reconciler *JobReconciler) Reconcile(definitions []JobDefinition) (ReconcileResult, error) {
+	reconciler.mu.Lock()
+	defer reconciler.mu.Unlock()
+
+	var (
+		result  ReconcileResult
+		errs    []error
+		desired = make(map[string]Job)
+		invalid = make(map[string]bool)
+		defined = make(map[string]bool)
+	)
+
+	for index, definition := range definitions {
+		name := definition.Name
+
+		if name == "" {
+			name = fmt.Sprintf("#%d", index+1)
+		}
+
+		if defined[name] {
+			errs = append(errs, fmt.Errorf("job %s : job is defined more than once", name))
+			delete(desired, name)
+			invalid[name] = true
+			continue
+		}
+
+		defined[name] = true
+
+		if !definition.IsEnabled() {
+			continue
+		}
+
+		job, err := definition.Job()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s : %w", name, err))
+			invalid[name] = true
+			continue
+		}
+
+		desired[name] = job
+	}
+
+	for _, name := range sortedJobNames(reconciler.jobs) {
+		if _, ok := desired[name]; ok || invalid[name] {
+			continue
+		}
+
+		reconciler.cancel(name)
+		delete(reconciler.jobs, name)
+		result.Removed = append(result.Removed, name)
+	}
+
+	for _, name := range sortedJobNames(desired) {
+		job := desired[name]
+		current, ok := reconciler.jobs[name]
+
+		if ok && sameJob(current, job) {
+			continue
+		}
+
+		var err error
+
+		switch {
+		case !ok:
+			if _, err = reconciler.scheduler.scheduleJob(job, JobState{}, false); err == nil {
+				result.Added = append(result.Added, name)
+			}
+		case sameJob(withoutSchedule(current), withoutSchedule(job)) && reconciler.reschedule(job) == nil:
+			result.Rescheduled = append(result.Rescheduled, name)
+		case current.isOneShot() && job.isOneShot() && !job.startTime().After(time.Now()) && reconciler.done(name):
+			// a one-shot job which has already been run is not run again unless it is moved to a later time
+			result.Updated = append(result.Updated, name)
+		default:
+			if err = reconciler.replace(current, job); err == nil {
+				result.Updated = append(result.Updated, name)
+			} else {
+				// the job is cancelled, so it is scheduled again as a new job by the next reconciliation
+				delete(reconciler.jobs, name)
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s : %w", name, err))
+			continue
+		}
+
+		reconciler.jobs[name] = job
+	}
+
+	reconciler.scheduler.loggerHolder.get().Debug("jobs reconciled",
+		slog.Int("added", len(result.Added)),
+		slog.Int("updated", len(result.Updated)),
+		slog.Int("rescheduled", len(result.Rescheduled)),
+		slog.Int("removed", len(result.Removed)))
+
+	return result, errors.Join(errs...)
+}
+
+// Jobs returns the jobs managed by the reconciler sorted by their names.
+func (reconciler *JobReconciler) Jobs() []Job {
+	reconciler.mu.Lock()
+	defer reconciler.mu.Unlock()
+
+	jobs := make([]Job, 0, len(reconciler.jobs))
+
+	for _, name := range sortedJobNames(reconciler.jobs) {
+		jobs = append(jobs, reconciler.jobs[name])
+	}
+
+	return jobs
+}
+
+func (reconciler *JobReconciler) cancel(name string) *namedTask {
+	namedTask, err := reconciler.scheduler.getNamedTask(name)
+
+	if err != nil {
+		return nil
+	}
+
+	namedTask.scheduledTask.Cancel()
+	return namedTask
+}
+
+// reschedule changes the schedule of the task of the job without scheduling it again.
+func (reconciler *JobReconciler) reschedule(job Job) error {
+	var options []Option
+
+	// a one-shot task keeps its trigger time unless a start time is given, whereas a one-shot job without
+	// a start time is run immediately
+	switch {
+	case !job.StartTime.IsZero():
+		options = append(options, WithTime(job.startTime()))
+	case job.isOneShot():
+		options = append(options, WithTime(time.Now()))
+	}
+
+	// the location is always given, so that the location the task has been scheduled with is not kept
+	// when it is removed from the job
+	location := job.Location
+
+	if location == "" {
+		location = "Local"
+	}
+
+	options = append(options, WithLocation(location))
+
+	switch {
+	case job.Cron != "":
+		return reconciler.scheduler.RescheduleWithCron(job.Name, job.Cron, options...)
+	case job.FixedDelay != 0:
+		return reconciler.scheduler.RescheduleWithFixedDelay(job.Name, job.FixedDelay, options...)
+	case job.FixedRate != 0:
+		return reconciler.scheduler.RescheduleAtFixedRate(job.Name, job.FixedRate, options...)
+	}
+
+	return reconciler.scheduler.Reschedule(job.Name, options...)
+}
+
+// replace cancels the task of the job and schedules it again in its place with the trigger context of its cron task.
+func (reconciler *JobReconciler) replace(current Job, job Job) error {
+	var (
+		state   JobState
+		options []Option
+	)
+
+	if namedTask := reconciler.cancel(job.Name); namedTask != nil {
+		switch scheduledTask := namedTask.scheduledTask.(type) {
+		case *TriggerTask:
+			scheduledTask.triggerContextMu.RLock()
+			state = newJobState(scheduledTask.triggerContext)
+			options = append(options, withReplacedTask(scheduledTask.id, scheduledTask.history))
+			scheduledTask.triggerContextMu.RUnlock()
+		case *ScheduledRunnableTask:
+			options = append(options, withReplacedTask(scheduledTask.id, scheduledTask.history))
+		}
+	}
+
+	// the executions of a changed cron expression since the last triggered one must not be recovered as missed ones
+	if current.Cron != job.Cron || current.Location != job.Location {
+		state.LastTriggeredExecutionTime = time.Time{}
+	}
+
+	_, err := reconciler.scheduler.scheduleJob(job, state, false, options...)
+	return err
+}
+
+// done reports whether the one-shot task of the job is not waiting in the queue of the executor anymore. A one-shot
+// task is marked as cancelled once it has been run, so it cannot be told apart from a cancelled one.
+func (reconciler *JobReconciler) done(name string) bool {
+	namedTask, err := reconciler.scheduler.getNamedTask(name)
+
+	if err != nil {
+		return false
+	}
+
+	for _, queuedTask := range reconciler.scheduler.Snapshot().QueuedTasks {
+		if queuedTask.ID == taskID(namedTask.scheduledTask) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withoutSchedule returns the job without the fields which can be changed by rescheduling its task. The kind
+// of the trigger is kept, since a task cannot be rescheduled with another kind of trigger.
+func withoutSchedule(job Job) Job {
+	job.StartTime = time.Time{}
+	job.Location = ""
+
+	if job.Cron != "" {
+		job.Cron = "*"
+	}
+
+	if job.FixedDelay != 0 {
+		job.FixedDelay = 1
+	}
+
+	if job.FixedRate != 0 {
+		job.FixedRate = 1
+	}
+
+	return job
+}
+
+func sameJob(job Job, other Job) bool {
+	if !job.StartTime.Equal(other.StartTime) {
+		return false
+	}
+
+	job.StartTime = other.StartTime
+	return job == other
+}
+
+func sortedJobNames(jobs map[string]Job) []string {
+	names := make([]string, 0, len(jobs))
+
+	for name := range jobs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
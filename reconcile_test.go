@@ -0,0 +1,274 @@
+package chrono
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewJobReconciler_WithNilScheduler(t *testing.T) {
+	reconciler, err := NewJobReconciler(nil)
+	assert.Nil(t, reconciler)
+	assert.EqualError(t, err, "scheduler cannot be nil")
+}
+
+func TestJobReconciler_Reconcile(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	var counter int32
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}))
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	result, err := reconciler.Reconcile([]JobDefinition{
+		{Name: "cron-job", Task: "task", Cron: "* * * * * *"},
+		{Name: "rate-job", Task: "task", FixedRate: "100ms"},
+		{Name: "one-shot-job", Task: "task", StartTime: time.Now().Add(time.Hour)},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Added: []string{"cron-job", "one-shot-job", "rate-job"}}, result)
+
+	<-time.After(1200 * time.Millisecond)
+
+	cronTask, _ := scheduler.GetTask("cron-job")
+	rateTask, _ := scheduler.GetTask("rate-job")
+	oneShotTask, _ := scheduler.GetTask("one-shot-job")
+	assert.NotEmpty(t, cronTask.(HistoryProvider).History())
+	assert.NotEmpty(t, rateTask.(HistoryProvider).History())
+
+	cronTask.(*TriggerTask).triggerContextMu.RLock()
+	lastCompletionTime := cronTask.(*TriggerTask).triggerContext.LastCompletionTime()
+	cronTask.(*TriggerTask).triggerContextMu.RUnlock()
+	assert.False(t, lastCompletionTime.IsZero())
+
+	result, err = reconciler.Reconcile([]JobDefinition{
+		{Name: "cron-job", Task: "task", Cron: "* * * * * *", Timeout: "1m"},
+		{Name: "rate-job", Task: "task", FixedRate: "50ms"},
+		{Name: "delay-job", Task: "task", FixedDelay: "1h"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{
+		Added:       []string{"delay-job"},
+		Updated:     []string{"cron-job"},
+		Rescheduled: []string{"rate-job"},
+		Removed:     []string{"one-shot-job"},
+	}, result)
+
+	assert.True(t, cronTask.IsCancelled())
+	assert.True(t, oneShotTask.IsCancelled())
+
+	updatedCronTask, _ := scheduler.GetTask("cron-job")
+	assert.NotEqual(t, cronTask, updatedCronTask)
+	assert.False(t, updatedCronTask.IsCancelled())
+	assert.Equal(t, cronTask.(Identifiable).ID(), updatedCronTask.(Identifiable).ID())
+	assert.Equal(t, cronTask.(HistoryProvider).History(), updatedCronTask.(HistoryProvider).History())
+
+	updatedCronTask.(*TriggerTask).triggerContextMu.RLock()
+	assert.Equal(t, lastCompletionTime, updatedCronTask.(*TriggerTask).triggerContext.LastCompletionTime())
+	updatedCronTask.(*TriggerTask).triggerContextMu.RUnlock()
+
+	rescheduledRateTask, _ := scheduler.GetTask("rate-job")
+	assert.Equal(t, rateTask, rescheduledRateTask)
+	assert.False(t, rateTask.IsCancelled())
+	assert.NotEmpty(t, rateTask.(HistoryProvider).History())
+
+	result, err = reconciler.Reconcile([]JobDefinition{
+		{Name: "cron-job", Task: "task", Cron: "* * * * * *", Timeout: "1m"},
+		{Name: "rate-job", Task: "task", FixedRate: "50ms"},
+		{Name: "delay-job", Task: "task", FixedDelay: "1h"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{}, result)
+
+	unchangedCronTask, _ := scheduler.GetTask("cron-job")
+	assert.Equal(t, updatedCronTask, unchangedCronTask)
+
+	jobs := reconciler.Jobs()
+	assert.Len(t, jobs, 3)
+	assert.Equal(t, "cron-job", jobs[0].Name)
+	assert.Equal(t, time.Minute, jobs[0].Timeout)
+}
+
+func TestJobReconciler_ReconcileWithInvalidDefinitions(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	_, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {}, time.Hour, WithName("other-task"))
+	assert.Nil(t, err)
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	result, err := reconciler.Reconcile([]JobDefinition{
+		{Name: "rate-job", Task: "task", FixedRate: "1h"},
+		{Name: "delay-job", Task: "task", FixedDelay: "1h"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"delay-job", "rate-job"}, result.Added)
+
+	rateTask, _ := scheduler.GetTask("rate-job")
+	disabled := false
+
+	result, err = reconciler.Reconcile([]JobDefinition{
+		{Name: "rate-job", Task: "task", FixedRate: "1 hour"},
+		{Name: "delay-job", Task: "task", FixedDelay: "1h"},
+		{Name: "delay-job", Task: "task", FixedDelay: "2h", Enabled: &disabled},
+		{Name: "other-task", Task: "task", FixedRate: "1h"},
+		{Name: "unknown-job", Task: "unknown", FixedRate: "1h"},
+	})
+	assert.EqualError(t, err, "job rate-job : invalid fixed rate : 1 hour\n"+
+		"job delay-job : job is defined more than once\n"+
+		"job other-task : task with name other-task is already scheduled\n"+
+		"job unknown-job : no task registered with name unknown")
+	assert.Equal(t, ReconcileResult{}, result)
+
+	assert.False(t, rateTask.IsCancelled())
+
+	delayTask, ok := scheduler.GetTask("delay-job")
+	assert.True(t, ok)
+	assert.False(t, delayTask.IsCancelled())
+
+	otherTask, _ := scheduler.GetTask("other-task")
+	assert.False(t, otherTask.IsCancelled())
+
+	assert.Len(t, reconciler.Jobs(), 2)
+}
+
+func TestJobReconciler_ReconcileDisabledJob(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	_, err = reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", FixedRate: "1h"}})
+	assert.Nil(t, err)
+
+	task, _ := scheduler.GetTask("job")
+	disabled := false
+
+	result, err := reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", FixedRate: "1h", Enabled: &disabled}})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Removed: []string{"job"}}, result)
+	assert.True(t, task.IsCancelled())
+	assert.Empty(t, reconciler.Jobs())
+}
+
+func TestJobReconciler_ReconcileOneShotJob(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	var counter int32
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}))
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	startTime := time.Now()
+
+	_, err = reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", StartTime: startTime}})
+	assert.Nil(t, err)
+
+	<-time.After(500 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+
+	task, _ := scheduler.GetTask("job")
+
+	result, err := reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", StartTime: startTime, Timeout: "1m"}})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Updated: []string{"job"}}, result)
+
+	result, err = reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", StartTime: startTime.Add(time.Millisecond), Timeout: "1m"}})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Updated: []string{"job"}}, result)
+
+	<-time.After(500 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+
+	unchangedTask, _ := scheduler.GetTask("job")
+	assert.Equal(t, task, unchangedTask)
+
+	result, err = reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", StartTime: time.Now().Add(2 * time.Second), Timeout: "1m"}})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Updated: []string{"job"}}, result)
+
+	<-time.After(500 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+
+	<-time.After(2 * time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&counter))
+
+	replacedTask, _ := scheduler.GetTask("job")
+	assert.NotEqual(t, task, replacedTask)
+	assert.Equal(t, task.(Identifiable).ID(), replacedTask.(Identifiable).ID())
+	assert.Len(t, replacedTask.(HistoryProvider).History(), 2)
+}
+
+func TestJobReconciler_ReconcileRescheduledPeriod(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	var counter int32
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}))
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	_, err = reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", FixedRate: "1h"}})
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+
+	result, err := reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", FixedRate: "500ms"}})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Rescheduled: []string{"job"}}, result)
+
+	<-time.After(300 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter), "rescheduled task must not be run immediately")
+
+	<-time.After(400 * time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&counter))
+}
+
+func TestJobReconciler_ReconcileRemovedLocation(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	_, err = reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", Cron: "0 0 12 * * *", Location: "Asia/Tokyo"}})
+	assert.Nil(t, err)
+
+	result, err := reconciler.Reconcile([]JobDefinition{{Name: "job", Task: "task", Cron: "0 0 12 * * *"}})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Rescheduled: []string{"job"}}, result)
+
+	task, _ := scheduler.GetTask("job")
+	nextTime := time.Now().Add(-time.Second)
+
+	for _, queuedTask := range scheduler.Snapshot().QueuedTasks {
+		if queuedTask.ID == task.(Identifiable).ID() {
+			nextTime = queuedTask.NextTriggerTime
+		}
+	}
+
+	assert.Equal(t, 12, nextTime.In(time.Local).Hour())
+}
@@ -1,6 +1,13 @@
 package chrono
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,7 +21,58 @@ type TaskScheduler interface {
 }
 
 type SimpleTaskScheduler struct {
-	taskExecutor TaskExecutor
+	taskExecutor     TaskExecutor
+	tasks            map[string]*namedTask
+	tasksMu          sync.RWMutex
+	middlewares      []Middleware
+	middlewaresMu    sync.RWMutex
+	loggerHolder     loggerHolder
+	registeredTasks  map[string]Task
+	jobStore         JobStore
+	jobStoreListened bool
+	jobsMu           sync.RWMutex
+	electionCancel   context.CancelFunc
+	electionDone     chan struct{}
+	leader           atomic.Bool
+	electionMu       sync.Mutex
+}
+
+type taskListenerRegistry interface {
+	AddListener(listener TaskListener)
+}
+
+type historySinkRegistry interface {
+	AddHistorySink(sink HistorySink)
+}
+
+type loggerSetter interface {
+	SetLogger(logger *slog.Logger)
+}
+
+type metricsSetter interface {
+	SetMetrics(metrics Metrics)
+}
+
+type tracerSetter interface {
+	SetTracer(tracer Tracer)
+}
+
+type lockerSetter interface {
+	SetLocker(locker Locker)
+}
+
+type standbySetter interface {
+	setStandby(standby bool)
+}
+
+type snapshotProvider interface {
+	Snapshot() ExecutorSnapshot
+}
+
+type namedTask struct {
+	scheduledTask ScheduledTask
+	schedulerTask *SchedulerTask
+	job           *Job
 }
 
 func NewSimpleTaskScheduler(executor TaskExecutor) *SimpleTaskScheduler {
@@ -24,7 +82,9 @@ func NewSimpleTaskScheduler(executor TaskExecutor) *SimpleTaskScheduler {
 	}
 
 	scheduler := &SimpleTaskScheduler{
-		taskExecutor: executor,
+		taskExecutor:    executor,
+		tasks:           make(map[string]*namedTask),
+		registeredTasks: make(map[string]Task),
 	}
 
 	return scheduler
@@ -35,20 +95,22 @@ func NewDefaultTaskScheduler() TaskScheduler {
 }
 
 func (scheduler *SimpleTaskScheduler) Schedule(task Task, options ...Option) (ScheduledTask, error) {
-	schedulerTask, err := CreateSchedulerTask(task, options...)
+	schedulerTask, err := scheduler.createSchedulerTask(task, options...)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return scheduler.taskExecutor.Schedule(task, schedulerTask.GetInitialDelay())
+	return scheduler.register(schedulerTask, func() (ScheduledTask, error) {
+		return scheduleTask(scheduler.taskExecutor, schedulerTask.task, schedulerTask.GetInitialDelay(), 0, false, options...)
+	})
 }
 
 func (scheduler *SimpleTaskScheduler) ScheduleWithCron(task Task, expression string, options ...Option) (ScheduledTask, error) {
 	var schedulerTask *SchedulerTask
 	var err error
 
-	schedulerTask, err = CreateSchedulerTask(task, options...)
+	schedulerTask, err = scheduler.createSchedulerTask(task, options...)
 
 	if err != nil {
 		return nil, err
@@ -62,39 +124,742 @@ func (scheduler *SimpleTaskScheduler) ScheduleWithCron(task Task, expression str
 	}
 
 	var triggerTask *TriggerTask
-	triggerTask, err = CreateTriggerTask(schedulerTask.task, scheduler.taskExecutor, cronTrigger)
+	triggerTask, err = CreateTriggerTask(schedulerTask.task, scheduler.taskExecutor, cronTrigger, options...)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return triggerTask.Schedule()
+	return scheduler.register(schedulerTask, triggerTask.Schedule)
+}
+
+// ScheduleWithTrigger schedules the task to be run at the execution times of the given trigger such as
+// an event trigger.
+func (scheduler *SimpleTaskScheduler) ScheduleWithTrigger(task Task, trigger Trigger, options ...Option) (ScheduledTask, error) {
+	schedulerTask, err := scheduler.createSchedulerTask(task, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var triggerTask *TriggerTask
+	triggerTask, err = CreateTriggerTask(schedulerTask.task, scheduler.taskExecutor, trigger, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduler.register(schedulerTask, triggerTask.Schedule)
 }
 
 func (scheduler *SimpleTaskScheduler) ScheduleWithFixedDelay(task Task, delay time.Duration, options ...Option) (ScheduledTask, error) {
-	schedulerTask, err := CreateSchedulerTask(task, options...)
+	schedulerTask, err := scheduler.createSchedulerTask(task, options...)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return scheduler.taskExecutor.ScheduleWithFixedDelay(schedulerTask.task, schedulerTask.GetInitialDelay(), delay)
+	return scheduler.register(schedulerTask, func() (ScheduledTask, error) {
+		return scheduleTask(scheduler.taskExecutor, schedulerTask.task, schedulerTask.GetInitialDelay(), delay, false, options...)
+	})
 }
 
 func (scheduler *SimpleTaskScheduler) ScheduleAtFixedRate(task Task, period time.Duration, options ...Option) (ScheduledTask, error) {
-	schedulerTask, err := CreateSchedulerTask(task, options...)
+	schedulerTask, err := scheduler.createSchedulerTask(task, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduler.register(schedulerTask, func() (ScheduledTask, error) {
+		return scheduleTask(scheduler.taskExecutor, schedulerTask.task, schedulerTask.GetInitialDelay(), period, true, options...)
+	})
+}
+
+// Use registers the middlewares applied to all the tasks scheduled afterwards. The middlewares
+// registered on the scheduler wrap the ones specified for a task using WithMiddleware.
+func (scheduler *SimpleTaskScheduler) Use(middlewares ...Middleware) {
+	scheduler.middlewaresMu.Lock()
+	defer scheduler.middlewaresMu.Unlock()
+
+	for _, middleware := range middlewares {
+		if middleware != nil {
+			scheduler.middlewares = append(scheduler.middlewares, middleware)
+		}
+	}
+}
+
+// AddListener registers the listener on the executor of the scheduler
+// if the executor supports listeners.
+func (scheduler *SimpleTaskScheduler) AddListener(listener TaskListener) {
+	if registry, ok := scheduler.taskExecutor.(taskListenerRegistry); ok {
+		registry.AddListener(listener)
+	}
+}
+
+// AddHistorySink adds a sink receiving the record of each execution if the executor of the scheduler supports it.
+func (scheduler *SimpleTaskScheduler) AddHistorySink(sink HistorySink) {
+	if registry, ok := scheduler.taskExecutor.(historySinkRegistry); ok {
+		registry.AddHistorySink(sink)
+	}
+}
+
+// SetLogger sets the logger used by the scheduler, which is also set on the executor
+// of the scheduler if the executor supports logging. Nothing is logged by default.
+func (scheduler *SimpleTaskScheduler) SetLogger(logger *slog.Logger) {
+	scheduler.loggerHolder.set(logger)
+
+	if setter, ok := scheduler.taskExecutor.(loggerSetter); ok {
+		setter.SetLogger(logger)
+	}
+}
+
+// SetMetrics sets the metrics backend on the executor of the scheduler if the executor supports metrics.
+func (scheduler *SimpleTaskScheduler) SetMetrics(metrics Metrics) {
+	if setter, ok := scheduler.taskExecutor.(metricsSetter); ok {
+		setter.SetMetrics(metrics)
+	}
+}
+
+// SetLocker sets the locker on the executor of the scheduler if the executor supports locking.
+func (scheduler *SimpleTaskScheduler) SetLocker(locker Locker) {
+	if setter, ok := scheduler.taskExecutor.(lockerSetter); ok {
+		setter.SetLocker(locker)
+	}
+}
+
+// SetTracer sets the tracer on the executor of the scheduler if the executor supports tracing.
+func (scheduler *SimpleTaskScheduler) SetTracer(tracer Tracer) {
+	if setter, ok := scheduler.taskExecutor.(tracerSetter); ok {
+		setter.SetTracer(tracer)
+	}
+}
+
+// Snapshot returns the snapshot of the executor of the scheduler. An empty snapshot is returned
+// if the executor does not support snapshots.
+func (scheduler *SimpleTaskScheduler) Snapshot() ExecutorSnapshot {
+	if provider, ok := scheduler.taskExecutor.(snapshotProvider); ok {
+		return provider.Snapshot()
+	}
+
+	return ExecutorSnapshot{
+		QueuedTasks:       []TaskSnapshot{},
+		RunningExecutions: []ExecutionSnapshot{},
+	}
+}
+
+func (scheduler *SimpleTaskScheduler) GetTask(name string) (ScheduledTask, bool) {
+	scheduler.tasksMu.RLock()
+	defer scheduler.tasksMu.RUnlock()
+
+	namedTask, ok := scheduler.tasks[name]
+
+	if !ok {
+		return nil, false
+	}
+
+	return namedTask.scheduledTask, true
+}
+
+func (scheduler *SimpleTaskScheduler) Reschedule(name string, options ...Option) error {
+	scheduledTask, schedulerTask, err := scheduler.getRunnableTask(name, options...)
+
+	if err != nil {
+		return err
+	}
+
+	if scheduledTask.isPeriodic() {
+		return fmt.Errorf("task %s is not a one-shot task", name)
+	}
+
+	// a one-shot task keeps its trigger time unless a new start time is given
+	delay := time.Until(scheduledTask.getTriggerTime())
+
+	if !schedulerTask.startTime.IsZero() {
+		delay = schedulerTask.GetInitialDelay()
+	}
+
+	if err = scheduledTask.Reschedule(delay, 0); err != nil {
+		return err
+	}
+
+	scheduler.keepOptions(name, schedulerTask)
+	return nil
+}
+
+func (scheduler *SimpleTaskScheduler) RescheduleWithCron(name string, expression string, options ...Option) error {
+	namedTask, err := scheduler.getNamedTask(name)
+
+	if err != nil {
+		return err
+	}
+
+	triggerTask, ok := namedTask.scheduledTask.(*TriggerTask)
+
+	if !ok {
+		return fmt.Errorf("task %s is not a cron task", name)
+	}
+
+	var schedulerTask *SchedulerTask
+	schedulerTask, err = scheduler.rescheduledTask(namedTask, options)
+
+	if err != nil {
+		return err
+	}
+
+	var cronTrigger *CronTrigger
+	cronTrigger, err = CreateCronTrigger(expression, schedulerTask.location)
+
+	if err != nil {
+		return err
+	}
+
+	if err = triggerTask.Reschedule(cronTrigger); err != nil {
+		return err
+	}
+
+	scheduler.keepOptions(name, schedulerTask)
+	return nil
+}
+
+func (scheduler *SimpleTaskScheduler) RescheduleWithFixedDelay(name string, delay time.Duration, options ...Option) error {
+	scheduledTask, schedulerTask, err := scheduler.getRunnableTask(name, options...)
+
+	if err != nil {
+		return err
+	}
+
+	if !scheduledTask.isPeriodic() || scheduledTask.isFixedRate() {
+		return fmt.Errorf("task %s is not a fixed-delay task", name)
+	}
+
+	if err = scheduledTask.Reschedule(rescheduledDelay(schedulerTask, delay), delay); err != nil {
+		return err
+	}
+
+	scheduler.keepOptions(name, schedulerTask)
+	return nil
+}
+
+func (scheduler *SimpleTaskScheduler) RescheduleAtFixedRate(name string, period time.Duration, options ...Option) error {
+	scheduledTask, schedulerTask, err := scheduler.getRunnableTask(name, options...)
+
+	if err != nil {
+		return err
+	}
+
+	if !scheduledTask.isPeriodic() || !scheduledTask.isFixedRate() {
+		return fmt.Errorf("task %s is not a fixed-rate task", name)
+	}
+
+	if err = scheduledTask.Reschedule(rescheduledDelay(schedulerTask, period), period); err != nil {
+		return err
+	}
+
+	scheduler.keepOptions(name, schedulerTask)
+	return nil
+}
+
+func (scheduler *SimpleTaskScheduler) TriggerNow(name string) error {
+	namedTask, err := scheduler.getNamedTask(name)
+
+	if err != nil {
+		return err
+	}
+
+	triggerable, ok := namedTask.scheduledTask.(Triggerable)
+
+	if !ok {
+		return fmt.Errorf("task %s cannot be triggered", name)
+	}
+
+	return triggerable.TriggerNow()
+}
+
+func (scheduler *SimpleTaskScheduler) History(name string) ([]ExecutionRecord, error) {
+	namedTask, err := scheduler.getNamedTask(name)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return scheduler.taskExecutor.ScheduleAtFixedRate(schedulerTask.task, schedulerTask.GetInitialDelay(), period)
+	provider, ok := namedTask.scheduledTask.(HistoryProvider)
+
+	if !ok {
+		return nil, fmt.Errorf("task %s does not keep its history", name)
+	}
+
+	return provider.History(), nil
+}
+
+// RegisterTask registers the task with the given name, so that the jobs can refer to it.
+func (scheduler *SimpleTaskScheduler) RegisterTask(taskName string, task Task) error {
+	if taskName == "" {
+		return errors.New("task name cannot be empty")
+	}
+
+	if task == nil {
+		return errors.New("task cannot be nil")
+	}
+
+	scheduler.jobsMu.Lock()
+	defer scheduler.jobsMu.Unlock()
+
+	if _, ok := scheduler.registeredTasks[taskName]; ok {
+		return fmt.Errorf("task with name %s is already registered", taskName)
+	}
+
+	scheduler.registeredTasks[taskName] = task
+	return nil
+}
+
+// SetJobStore sets the store the jobs scheduled by ScheduleJob and their states are persisted in.
+func (scheduler *SimpleTaskScheduler) SetJobStore(store JobStore) {
+	scheduler.jobsMu.Lock()
+	defer scheduler.jobsMu.Unlock()
+
+	scheduler.jobStore = store
+
+	if store != nil && !scheduler.jobStoreListened {
+		scheduler.jobStoreListened = true
+		scheduler.AddListener(jobStoreListener{scheduler: scheduler})
+	}
+}
+
+// ScheduleJob schedules the task registered with the task name of the job, and saves the job
+// in the job store if there is one. The job is deleted from the store once it is cancelled or,
+// if it is a one-shot job, once it is run.
+func (scheduler *SimpleTaskScheduler) ScheduleJob(job Job) (ScheduledTask, error) {
+	if err := job.validate(); err != nil {
+		return nil, err
+	}
+
+	if existingTask, ok := scheduler.GetTask(job.Name); ok && !existingTask.IsCancelled() {
+		return nil, fmt.Errorf("task with name %s is already scheduled", job.Name)
+	}
+
+	scheduler.jobsMu.RLock()
+	store := scheduler.jobStore
+	scheduler.jobsMu.RUnlock()
+
+	var previousJob *StoredJob
+
+	if store != nil {
+		var err error
+		previousJob, err = findStoredJob(store, job.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = store.SaveJob(job); err != nil {
+			return nil, err
+		}
+	}
+
+	scheduledTask, err := scheduler.scheduleJob(job, JobState{}, true)
+
+	if err != nil && store != nil {
+		if restoreErr := restoreStoredJob(store, job.Name, previousJob); restoreErr != nil {
+			scheduler.loggerHolder.get().Error("job could not be restored in job store",
+				slog.String("task_name", job.Name), slog.Any("error", restoreErr))
+		}
+	}
+
+	return scheduledTask, err
+}
+
+// LoadJobs schedules the jobs in the job store, which is supposed to be called on startup.
+// The jobs which cannot be scheduled are skipped, and the errors are returned altogether.
+func (scheduler *SimpleTaskScheduler) LoadJobs() error {
+	scheduler.jobsMu.RLock()
+	store := scheduler.jobStore
+	scheduler.jobsMu.RUnlock()
+
+	if store == nil {
+		return errors.New("no job store is set")
+	}
+
+	storedJobs, err := store.LoadJobs()
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, storedJob := range storedJobs {
+		if _, err = scheduler.scheduleJob(storedJob.Job, storedJob.State, true); err != nil {
+			scheduler.loggerHolder.get().Error("job could not be loaded",
+				slog.String("task_name", storedJob.Job.Name), slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("job %s could not be loaded : %w", storedJob.Job.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// scheduleJob schedules the job with the given state and options. The state of the job is kept in the job store
+// only if the job is persisted.
+func (scheduler *SimpleTaskScheduler) scheduleJob(job Job, state JobState, persisted bool, extraOptions ...Option) (ScheduledTask, error) {
+	if err := job.validate(); err != nil {
+		return nil, err
+	}
+
+	scheduler.jobsMu.RLock()
+	task, ok := scheduler.registeredTasks[job.TaskName]
+	scheduler.jobsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no task registered with name %s", job.TaskName)
+	}
+
+	options := job.options()
+
+	if persisted {
+		options = append(options, withJob(&job))
+	}
+
+	options = append(options, extraOptions...)
+
+	switch {
+	case job.Cron != "":
+		triggerContext := NewSimpleTriggerContext()
+		triggerContext.Update(state.LastCompletionTime, state.LastExecutionTime, state.LastTriggeredExecutionTime)
+		return scheduler.ScheduleWithCron(task, job.Cron, append(options, withTriggerContext(triggerContext))...)
+	case job.FixedDelay != 0:
+		return scheduler.ScheduleWithFixedDelay(task, job.FixedDelay, options...)
+	case job.FixedRate != 0:
+		return scheduler.ScheduleAtFixedRate(task, job.FixedRate, options...)
+	}
+
+	return scheduler.Schedule(task, options...)
+}
+
+// getJob returns the job with the given name if it is still the task with the given id.
+func (scheduler *SimpleTaskScheduler) getJob(name string, id int) (*namedTask, JobStore) {
+	scheduler.tasksMu.RLock()
+	namedTask, ok := scheduler.tasks[name]
+	scheduler.tasksMu.RUnlock()
+
+	if !ok || namedTask.job == nil || taskID(namedTask.scheduledTask) != id {
+		return nil, nil
+	}
+
+	scheduler.jobsMu.RLock()
+	defer scheduler.jobsMu.RUnlock()
+	return namedTask, scheduler.jobStore
+}
+
+// Pause pauses the executor of the scheduler. Nothing is done if the executor cannot be paused.
+func (scheduler *SimpleTaskScheduler) Pause() {
+	if pausable, ok := scheduler.taskExecutor.(Pausable); ok {
+		pausable.Pause()
+	}
+}
+
+func (scheduler *SimpleTaskScheduler) Resume() {
+	if pausable, ok := scheduler.taskExecutor.(Pausable); ok {
+		pausable.Resume()
+	}
+}
+
+func (scheduler *SimpleTaskScheduler) IsPaused() bool {
+	pausable, ok := scheduler.taskExecutor.(Pausable)
+	return ok && pausable.IsPaused()
 }
 
 func (scheduler *SimpleTaskScheduler) IsShutdown() bool {
 	return scheduler.taskExecutor.IsShutdown()
 }
 
+// StartLeaderElection makes the scheduler run the tasks only while it is elected as the leader by the given
+// elector. The scheduler stays in standby until it is elected, and goes back to standby without dropping
+// its tasks when the leadership is lost. The election is stopped when the scheduler is shut down.
+func (scheduler *SimpleTaskScheduler) StartLeaderElection(elector LeaderElector) error {
+	if elector == nil {
+		return errors.New("elector cannot be nil")
+	}
+
+	setter, ok := scheduler.taskExecutor.(standbySetter)
+
+	if !ok {
+		return errors.New("executor of the scheduler does not support leader election")
+	}
+
+	scheduler.electionMu.Lock()
+	defer scheduler.electionMu.Unlock()
+
+	if scheduler.electionCancel != nil {
+		return errors.New("leader election is already started")
+	}
+
+	if scheduler.IsShutdown() {
+		return errors.New("leader election cannot be started because scheduler is already shut down")
+	}
+
+	setter.setStandby(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.electionCancel = cancel
+	scheduler.electionDone = make(chan struct{})
+
+	go func() {
+		defer close(scheduler.electionDone)
+
+		elector.Campaign(ctx, LeaderCallbacks{
+			OnElected: func() {
+				scheduler.leader.Store(true)
+				scheduler.loggerHolder.get().Info("scheduler is elected as the leader")
+				setter.setStandby(false)
+			},
+			OnRevoked: func() {
+				scheduler.leader.Store(false)
+				scheduler.loggerHolder.get().Info("scheduler lost the leadership")
+				setter.setStandby(true)
+			},
+			OnError: func(err error) {
+				scheduler.loggerHolder.get().Error("leader election failed", slog.Any("error", err))
+			},
+		})
+	}()
+
+	return nil
+}
+
+// IsLeader returns whether the scheduler is currently the leader. It is always false
+// unless the leader election is started.
+func (scheduler *SimpleTaskScheduler) IsLeader() bool {
+	return scheduler.leader.Load()
+}
+
+func (scheduler *SimpleTaskScheduler) stopLeaderElection() {
+	scheduler.electionMu.Lock()
+	defer scheduler.electionMu.Unlock()
+
+	if scheduler.electionCancel == nil {
+		return
+	}
+
+	// the leadership is given up before shutting down, so that another instance can take over
+	scheduler.electionCancel()
+	<-scheduler.electionDone
+}
+
 func (scheduler *SimpleTaskScheduler) Shutdown() chan bool {
+	scheduler.stopLeaderElection()
 	return scheduler.taskExecutor.Shutdown()
 }
+
+func (scheduler *SimpleTaskScheduler) createSchedulerTask(task Task, options ...Option) (*SchedulerTask, error) {
+	schedulerTask, err := CreateSchedulerTask(task, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler.middlewaresMu.RLock()
+	middlewares := make([]Middleware, 0, len(scheduler.middlewares)+len(schedulerTask.middlewares))
+	middlewares = append(middlewares, scheduler.middlewares...)
+	scheduler.middlewaresMu.RUnlock()
+
+	middlewares = append(middlewares, schedulerTask.middlewares...)
+
+	// each attempt of a retried execution has its own timeout
+	if schedulerTask.retryAttempts > 1 {
+		middlewares = append(middlewares, retryMiddleware(schedulerTask.retryAttempts, schedulerTask.retryDelay))
+	}
+
+	if schedulerTask.timeout > 0 {
+		middlewares = append(middlewares, timeoutMiddleware(schedulerTask.timeout))
+	}
+
+	schedulerTask.task = ChainMiddlewares(middlewares...)(schedulerTask.task)
+
+	if len(schedulerTask.followUps) != 0 {
+		followUps := make([]followUp, len(schedulerTask.followUps))
+
+		for index, followUp := range schedulerTask.followUps {
+			followUpTask, err := scheduler.createSchedulerTask(followUp.task, followUp.options...)
+
+			if err != nil {
+				return nil, err
+			}
+
+			followUp.task = followUpTask.task
+			followUps[index] = followUp
+		}
+
+		schedulerTask.task = followUpMiddleware(scheduler.taskExecutor, &scheduler.loggerHolder, followUps)(schedulerTask.task)
+	}
+
+	return schedulerTask, nil
+}
+
+func (scheduler *SimpleTaskScheduler) register(schedulerTask *SchedulerTask, schedule func() (ScheduledTask, error)) (ScheduledTask, error) {
+	if schedulerTask.name == "" {
+		return schedule()
+	}
+
+	scheduler.tasksMu.Lock()
+	defer scheduler.tasksMu.Unlock()
+
+	if existingTask, ok := scheduler.tasks[schedulerTask.name]; ok && !existingTask.scheduledTask.IsCancelled() {
+		scheduler.loggerHolder.get().Warn("task rejected because a task with the same name is already scheduled",
+			taskLogAttrs(taskID(existingTask.scheduledTask), schedulerTask.name)...)
+		return nil, fmt.Errorf("task with name %s is already scheduled", schedulerTask.name)
+	}
+
+	scheduledTask, err := schedule()
+
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler.tasks[schedulerTask.name] = &namedTask{
+		scheduledTask: scheduledTask,
+		schedulerTask: schedulerTask,
+		job:           schedulerTask.job,
+	}
+
+	scheduler.loggerHolder.get().Debug("task registered", taskLogAttrs(taskID(scheduledTask), schedulerTask.name)...)
+
+	return scheduledTask, nil
+}
+
+func (scheduler *SimpleTaskScheduler) getNamedTask(name string) (*namedTask, error) {
+	scheduler.tasksMu.RLock()
+	defer scheduler.tasksMu.RUnlock()
+
+	namedTask, ok := scheduler.tasks[name]
+
+	if !ok {
+		return nil, fmt.Errorf("no task found with name %s", name)
+	}
+
+	return namedTask, nil
+}
+
+func (scheduler *SimpleTaskScheduler) namedTasks() []*namedTask {
+	scheduler.tasksMu.RLock()
+	defer scheduler.tasksMu.RUnlock()
+
+	tasks := make([]*namedTask, 0, len(scheduler.tasks))
+
+	for _, task := range scheduler.tasks {
+		tasks = append(tasks, task)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].schedulerTask.name < tasks[j].schedulerTask.name
+	})
+
+	return tasks
+}
+
+func (scheduler *SimpleTaskScheduler) getRunnableTask(name string, options ...Option) (*ScheduledRunnableTask, *SchedulerTask, error) {
+	namedTask, err := scheduler.getNamedTask(name)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheduledTask, ok := namedTask.scheduledTask.(*ScheduledRunnableTask)
+
+	if !ok {
+		return nil, nil, errors.New("task cannot be rescheduled because it has not been scheduled by an executor")
+	}
+
+	var schedulerTask *SchedulerTask
+	schedulerTask, err = scheduler.rescheduledTask(namedTask, options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return scheduledTask, schedulerTask, nil
+}
+
+// rescheduledTask creates the scheduler task of a rescheduled task from the options it has been scheduled with,
+// and the given options overriding them. The start time of the task is not kept, so that it is rescheduled from now
+// on unless WithTime is given. Only the start time and the location of a task can be changed by rescheduling it.
+func (scheduler *SimpleTaskScheduler) rescheduledTask(namedTask *namedTask, options []Option) (*SchedulerTask, error) {
+	scheduler.tasksMu.RLock()
+	scheduledOptions := namedTask.schedulerTask.options
+	scheduler.tasksMu.RUnlock()
+
+	schedulerTask, err := CreateSchedulerTask(namedTask.schedulerTask.task, scheduledOptions...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schedulerTask.startTime = time.Time{}
+	settings := settingsOf(schedulerTask)
+
+	for _, option := range options {
+		if err = option(schedulerTask); err != nil {
+			return nil, err
+		}
+	}
+
+	if settingsOf(schedulerTask) != settings {
+		return nil, errors.New("options other than WithTime and WithLocation cannot be given to reschedule a task")
+	}
+
+	schedulerTask.options = append(append([]Option{}, scheduledOptions...), options...)
+	return schedulerTask, nil
+}
+
+// rescheduledDelay returns the delay before the next execution of a rescheduled periodic task. The next execution
+// is one period after now unless a new start time is given.
+func rescheduledDelay(schedulerTask *SchedulerTask, period time.Duration) time.Duration {
+	if schedulerTask.startTime.IsZero() {
+		return period
+	}
+
+	return schedulerTask.GetInitialDelay()
+}
+
+// taskSettings are the settings of a task which cannot be changed by rescheduling it.
+type taskSettings struct {
+	name              string
+	misfirePolicy     MisfirePolicy
+	concurrencyPolicy ConcurrencyPolicy
+	historySize       int
+	lockTTL           time.Duration
+	lockAtLeast       time.Duration
+	lockAtLeastSet    bool
+	timeout           time.Duration
+	retryAttempts     int
+	retryDelay        time.Duration
+	middlewares       int
+	followUps         int
+}
+
+func settingsOf(schedulerTask *SchedulerTask) taskSettings {
+	return taskSettings{
+		name:              schedulerTask.name,
+		misfirePolicy:     schedulerTask.misfirePolicy,
+		concurrencyPolicy: schedulerTask.concurrencyPolicy,
+		historySize:       schedulerTask.historySize,
+		lockTTL:           schedulerTask.lockTTL,
+		lockAtLeast:       schedulerTask.lockAtLeast,
+		lockAtLeastSet:    schedulerTask.lockAtLeastSet,
+		timeout:           schedulerTask.timeout,
+		retryAttempts:     schedulerTask.retryAttempts,
+		retryDelay:        schedulerTask.retryDelay,
+		middlewares:       len(schedulerTask.middlewares),
+		followUps:         len(schedulerTask.followUps),
+	}
+}
+
+// keepOptions keeps the options of a rescheduled task, so that they are overridden by the next rescheduling.
+func (scheduler *SimpleTaskScheduler) keepOptions(name string, schedulerTask *SchedulerTask) {
+	scheduler.tasksMu.Lock()
+	defer scheduler.tasksMu.Unlock()
+
+	if namedTask, ok := scheduler.tasks[name]; ok {
+		namedTask.schedulerTask.options = schedulerTask.options
+	}
+}
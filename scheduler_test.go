@@ -309,3 +309,262 @@ func TestSimpleTaskScheduler_Shutdown(t *testing.T) {
 	assert.Equal(t, expected, counter,
 		"after shutdown, previously scheduled tasks should not be rescheduled", counter)
 }
+
+type cancellableTaskExecutor struct {
+	TaskExecutor
+}
+
+func (executor cancellableTaskExecutor) Schedule(task Task, delay time.Duration) (ScheduledTask, error) {
+	scheduledTask, err := executor.TaskExecutor.Schedule(task, delay)
+	return struct{ ScheduledTask }{scheduledTask}, err
+}
+
+func TestSimpleTaskScheduler_WithExecutorImplementingOnlyTaskExecutor(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(cancellableTaskExecutor{NewDefaultTaskExecutor()})
+	defer func() { <-scheduler.Shutdown() }()
+
+	var counter int32
+	task, err := scheduler.Schedule(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, WithName("task"))
+	assert.Nil(t, err)
+
+	_, ok := task.(Pausable)
+	assert.False(t, ok)
+
+	scheduler.Pause()
+	assert.False(t, scheduler.IsPaused())
+
+	assert.EqualError(t, scheduler.TriggerNow("task"), "task task cannot be triggered")
+
+	_, err = scheduler.History("task")
+	assert.EqualError(t, err, "task task does not keep its history")
+
+	<-time.After(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+}
+
+func TestSimpleTaskScheduler_WithName(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+
+	task, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {
+	}, 1*time.Second, WithName("test-task"))
+
+	assert.Nil(t, err)
+
+	registeredTask, ok := scheduler.GetTask("test-task")
+	assert.True(t, ok)
+	assert.Equal(t, task, registeredTask)
+
+	_, err = scheduler.Schedule(func(ctx context.Context) {
+	}, WithName("test-task"))
+	assert.Error(t, err)
+
+	task.Cancel()
+
+	_, err = scheduler.Schedule(func(ctx context.Context) {
+	}, WithName("test-task"))
+	assert.Nil(t, err)
+
+	_, ok = scheduler.GetTask("unknown-task")
+	assert.False(t, ok)
+
+	_, err = scheduler.Schedule(func(ctx context.Context) {
+	}, WithName(""))
+	assert.Error(t, err)
+}
+
+func TestSimpleTaskScheduler_Reschedule(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+
+	var counter int32
+
+	task, err := scheduler.Schedule(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, WithName("one-shot"), WithTime(time.Now().Add(1*time.Hour)))
+
+	assert.Nil(t, err)
+
+	err = scheduler.Reschedule("one-shot", WithTime(time.Now().Add(1*time.Second)))
+	assert.Nil(t, err)
+
+	err = scheduler.Reschedule("one-shot", WithLocation("UTC"))
+	assert.Nil(t, err)
+
+	assert.Error(t, scheduler.RescheduleAtFixedRate("one-shot", time.Second))
+	assert.Error(t, scheduler.RescheduleWithFixedDelay("one-shot", time.Second))
+	assert.Error(t, scheduler.RescheduleWithCron("one-shot", "* * * * * *"))
+	assert.Error(t, scheduler.Reschedule("unknown-task"))
+
+	<-time.After(2 * time.Second)
+	assert.True(t, task.IsCancelled(), "scheduled task must have been cancelled")
+	assert.True(t, counter == 1,
+		"number of scheduled task execution must be 1, actual: %d", counter)
+}
+
+func TestSimpleTaskScheduler_RescheduleAtFixedRate(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+
+	var counter int32
+
+	task, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 1*time.Hour, WithName("fixed-rate"), WithTime(time.Now().Add(1*time.Hour)))
+
+	assert.Nil(t, err)
+
+	err = scheduler.RescheduleAtFixedRate("fixed-rate", 200*time.Millisecond)
+	assert.Nil(t, err)
+	assert.Error(t, scheduler.RescheduleWithFixedDelay("fixed-rate", time.Second))
+
+	<-time.After(100 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counter), "rescheduled task must not be run immediately")
+
+	<-time.After(1*time.Second - 150*time.Millisecond)
+	task.Cancel()
+	assert.True(t, counter >= 3 && counter <= 4,
+		"number of scheduled task execution must be between 3 and 4, actual: %d", counter)
+}
+
+func TestSimpleTaskScheduler_RescheduleWithFixedDelay(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+	defer func() { <-scheduler.Shutdown() }()
+
+	var counter int32
+
+	task, err := scheduler.ScheduleWithFixedDelay(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 1*time.Hour, WithName("fixed-delay"), WithTime(time.Now().Add(1*time.Hour)))
+
+	assert.Nil(t, err)
+
+	err = scheduler.RescheduleWithFixedDelay("fixed-delay", 300*time.Millisecond)
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counter), "rescheduled task must not be run immediately")
+
+	<-time.After(200 * time.Millisecond)
+	task.Cancel()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+}
+
+func TestSimpleTaskScheduler_Reschedule_InvalidOptions(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+	defer func() { <-scheduler.Shutdown() }()
+
+	_, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {}, 1*time.Hour,
+		WithName("fixed-rate"), WithTime(time.Now().Add(1*time.Hour)))
+	assert.Nil(t, err)
+
+	_, err = scheduler.ScheduleWithCron(func(ctx context.Context) {}, "0 0 9 * * *", WithName("cron"))
+	assert.Nil(t, err)
+
+	assert.EqualError(t, scheduler.RescheduleAtFixedRate("fixed-rate", time.Second, WithRetry(3, time.Second)),
+		"options other than WithTime and WithLocation cannot be given to reschedule a task")
+	assert.Error(t, scheduler.RescheduleAtFixedRate("fixed-rate", time.Second, WithName("other")))
+	assert.Error(t, scheduler.RescheduleWithCron("cron", "0 0 10 * * *", WithMisfirePolicy(MisfirePolicyRunAll)))
+	assert.Error(t, scheduler.RescheduleWithCron("cron", "0 0 10 * * *", WithMiddleware(func(next Task) Task {
+		return next
+	})))
+
+	assert.Nil(t, scheduler.RescheduleWithCron("cron", "0 0 10 * * *", WithName("cron"), WithLocation("UTC")))
+}
+
+func TestSimpleTaskScheduler_RescheduleWithCron(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+
+	var counter int32
+
+	task, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, "0 0 0 1 1 *", WithName("cron"))
+
+	assert.Nil(t, err)
+
+	err = scheduler.RescheduleWithCron("cron", "test * * * * *")
+	assert.Error(t, err)
+
+	err = scheduler.RescheduleWithCron("cron", "* * * * * *")
+	assert.Nil(t, err)
+	assert.Error(t, scheduler.RescheduleAtFixedRate("cron", time.Second))
+
+	<-time.After(2*time.Second + 100*time.Millisecond)
+
+	registeredTask, _ := scheduler.GetTask("cron")
+	assert.Equal(t, task, registeredTask)
+
+	task.Cancel()
+	assert.True(t, counter >= 1,
+		"number of scheduled task execution must be at least 1, actual: %d", counter)
+}
+
+func TestSimpleTaskScheduler_RescheduleWithCronKeepsOptions(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+	defer func() { <-scheduler.Shutdown() }()
+
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	assert.Nil(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.Nil(t, err)
+
+	task, err := scheduler.ScheduleWithCron(func(ctx context.Context) {}, "0 0 9 * * *",
+		WithName("cron"), WithLocation("Europe/Istanbul"))
+	assert.Nil(t, err)
+
+	triggerTask := task.(*TriggerTask)
+
+	assert.Nil(t, scheduler.RescheduleWithCron("cron", "0 0 10 * * *"))
+	assert.Equal(t, istanbul, triggerTask.trigger.(*CronTrigger).location)
+
+	assert.Nil(t, scheduler.RescheduleWithCron("cron", "0 0 11 * * *", WithLocation("Asia/Tokyo")))
+	assert.Equal(t, tokyo, triggerTask.trigger.(*CronTrigger).location)
+
+	assert.Nil(t, scheduler.RescheduleWithCron("cron", "0 0 12 * * *"))
+	assert.Equal(t, tokyo, triggerTask.trigger.(*CronTrigger).location)
+}
+
+func TestSimpleTaskScheduler_PauseAndResume(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+
+	var counter int32
+
+	task, err := scheduler.ScheduleAtFixedRate(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, 200*time.Millisecond, WithMisfirePolicy(MisfirePolicySkip))
+
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	scheduler.Pause()
+	assert.True(t, scheduler.IsPaused())
+	expected := atomic.LoadInt32(&counter)
+
+	<-time.After(1 * time.Second)
+	assert.Equal(t, expected, atomic.LoadInt32(&counter))
+
+	scheduler.Resume()
+	assert.False(t, scheduler.IsPaused())
+
+	<-time.After(500 * time.Millisecond)
+	task.Cancel()
+	assert.True(t, atomic.LoadInt32(&counter) > expected)
+}
+
+func TestSimpleTaskScheduler_TriggerNow(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewDefaultTaskExecutor())
+
+	var counter int32
+
+	_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, "0 0 0 1 1 *", WithName("cron"))
+	assert.Nil(t, err)
+
+	assert.Nil(t, scheduler.TriggerNow("cron"))
+	assert.Error(t, scheduler.TriggerNow("unknown"))
+
+	<-time.After(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+}
@@ -0,0 +1,158 @@
+package chrono
+
+import (
+	"sort"
+	"time"
+)
+
+type TaskState int
+
+const (
+	TaskStateScheduled TaskState = iota
+	TaskStateRunning
+	TaskStatePaused
+	TaskStateCancelled
+)
+
+func (state TaskState) String() string {
+	switch state {
+	case TaskStateScheduled:
+		return "scheduled"
+	case TaskStateRunning:
+		return "running"
+	case TaskStatePaused:
+		return "paused"
+	case TaskStateCancelled:
+		return "cancelled"
+	}
+
+	return "unknown"
+}
+
+type TaskSnapshot struct {
+	ID              int
+	Name            string
+	TriggerType     string
+	CronExpression  string
+	NextTriggerTime time.Time
+	Period          time.Duration
+	FixedRate       bool
+	State           TaskState
+}
+
+type ExecutionSnapshot struct {
+	TaskID        int
+	TaskName      string
+	ScheduledTime time.Time
+	StartTime     time.Time
+}
+
+type ExecutorSnapshot struct {
+	Paused            bool
+	QueuedTasks       []TaskSnapshot
+	RunningExecutions []ExecutionSnapshot
+}
+
+// Snapshot returns the tasks waiting in the queue ordered by their next trigger time
+// and the executions currently running ordered by their start time.
+func (executor *SimpleTaskExecutor) Snapshot() ExecutorSnapshot {
+	executor.executorMu.RLock()
+
+	if executor.isShutdown {
+		executor.executorMu.RUnlock()
+
+		return ExecutorSnapshot{
+			Paused:            executor.IsPaused(),
+			QueuedTasks:       []TaskSnapshot{},
+			RunningExecutions: executor.runningExecutionSnapshots(),
+		}
+	}
+
+	snapshotChan := make(chan ExecutorSnapshot, 1)
+	executor.snapshotChannel <- snapshotChan
+	executor.executorMu.RUnlock()
+
+	return <-snapshotChan
+}
+
+// snapshot must only be called by the run loop, which owns the task queue.
+func (executor *SimpleTaskExecutor) snapshot() ExecutorSnapshot {
+	executor.runningExecutionsMu.Lock()
+	runningTasks := make(map[*ScheduledRunnableTask]bool, len(executor.runningExecutions))
+
+	for _, task := range executor.runningExecutions {
+		runningTasks[task] = true
+	}
+
+	executor.runningExecutionsMu.Unlock()
+
+	queuedTasks := make([]TaskSnapshot, 0, len(executor.taskQueue))
+
+	for _, scheduledTask := range executor.taskQueue {
+		state := TaskStateScheduled
+
+		if scheduledTask.IsCancelled() {
+			state = TaskStateCancelled
+		} else if runningTasks[scheduledTask] {
+			state = TaskStateRunning
+		} else if scheduledTask.IsPaused() {
+			state = TaskStatePaused
+		}
+
+		triggerType, cronExpression := scheduledTask.describeTrigger()
+		queuedTasks = append(queuedTasks, TaskSnapshot{
+			ID:              scheduledTask.id,
+			Name:            scheduledTask.name,
+			TriggerType:     triggerType,
+			CronExpression:  cronExpression,
+			NextTriggerTime: scheduledTask.triggerTime,
+			Period:          scheduledTask.getPeriod(),
+			FixedRate:       scheduledTask.isFixedRate(),
+			State:           state,
+		})
+	}
+
+	sort.SliceStable(queuedTasks, func(i, j int) bool {
+		return queuedTasks[i].NextTriggerTime.Before(queuedTasks[j].NextTriggerTime)
+	})
+
+	return ExecutorSnapshot{
+		Paused:            executor.dispatchPaused,
+		QueuedTasks:       queuedTasks,
+		RunningExecutions: executor.runningExecutionSnapshots(),
+	}
+}
+
+func (executor *SimpleTaskExecutor) runningExecutionSnapshots() []ExecutionSnapshot {
+	executor.runningExecutionsMu.Lock()
+	defer executor.runningExecutionsMu.Unlock()
+
+	executions := make([]ExecutionSnapshot, 0, len(executor.runningExecutions))
+
+	for execution := range executor.runningExecutions {
+		executions = append(executions, ExecutionSnapshot{
+			TaskID:        execution.TaskID(),
+			TaskName:      execution.TaskName(),
+			ScheduledTime: execution.ScheduledTime(),
+			StartTime:     execution.StartTime(),
+		})
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].StartTime.Before(executions[j].StartTime)
+	})
+
+	return executions
+}
+
+func (executor *SimpleTaskExecutor) addRunningExecution(execution *Execution, task *ScheduledRunnableTask) {
+	executor.runningExecutionsMu.Lock()
+	defer executor.runningExecutionsMu.Unlock()
+	executor.runningExecutions[execution] = task
+}
+
+func (executor *SimpleTaskExecutor) removeRunningExecution(execution *Execution) {
+	executor.runningExecutionsMu.Lock()
+	defer executor.runningExecutionsMu.Unlock()
+	delete(executor.runningExecutions, execution)
+}
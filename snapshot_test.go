@@ -0,0 +1,121 @@
+package chrono
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSimpleTaskExecutor_Snapshot(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	fixedRateTask, err := executor.schedule(func(ctx context.Context) {
+	}, 1*time.Second, 2*time.Second, true, WithName("fixed-rate-task"))
+	assert.Nil(t, err)
+
+	pausedTask, err := executor.schedule(func(ctx context.Context) {
+	}, 2*time.Second, 1*time.Second, false, WithName("paused-task"))
+	assert.Nil(t, err)
+	pausedTask.(Pausable).Pause()
+
+	started := make(chan bool)
+	release := make(chan bool)
+
+	_, err = executor.schedule(func(ctx context.Context) {
+		started <- true
+		<-release
+	}, 0, 0, false, WithName("running-task"))
+	assert.Nil(t, err)
+
+	<-started
+	snapshot := executor.Snapshot()
+	close(release)
+
+	assert.False(t, snapshot.Paused)
+	assert.Len(t, snapshot.QueuedTasks, 2)
+
+	assert.Equal(t, fixedRateTask.(Identifiable).ID(), snapshot.QueuedTasks[0].ID)
+	assert.Equal(t, "fixed-rate-task", snapshot.QueuedTasks[0].Name)
+	assert.Equal(t, TriggerTypeFixedRate, snapshot.QueuedTasks[0].TriggerType)
+	assert.Equal(t, 2*time.Second, snapshot.QueuedTasks[0].Period)
+	assert.True(t, snapshot.QueuedTasks[0].FixedRate)
+	assert.Equal(t, TaskStateScheduled, snapshot.QueuedTasks[0].State)
+	assert.True(t, snapshot.QueuedTasks[0].NextTriggerTime.After(time.Now()))
+
+	assert.Equal(t, "paused-task", snapshot.QueuedTasks[1].Name)
+	assert.False(t, snapshot.QueuedTasks[1].FixedRate)
+	assert.Equal(t, TaskStatePaused, snapshot.QueuedTasks[1].State)
+
+	assert.Len(t, snapshot.RunningExecutions, 1)
+	assert.Equal(t, "running-task", snapshot.RunningExecutions[0].TaskName)
+	assert.False(t, snapshot.RunningExecutions[0].StartTime.IsZero())
+	assert.False(t, snapshot.RunningExecutions[0].StartTime.Before(snapshot.RunningExecutions[0].ScheduledTime))
+
+	<-time.After(50 * time.Millisecond)
+	assert.Empty(t, executor.Snapshot().RunningExecutions)
+}
+
+func TestSimpleTaskExecutor_Snapshot_RunningFixedRateTask(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	started := make(chan bool)
+	release := make(chan bool)
+
+	task, err := executor.ScheduleAtFixedRate(func(ctx context.Context) {
+		started <- true
+		<-release
+	}, 0, 1*time.Hour)
+	assert.Nil(t, err)
+
+	<-started
+	<-time.After(10 * time.Millisecond)
+	snapshot := executor.Snapshot()
+	close(release)
+	task.Cancel()
+
+	assert.Len(t, snapshot.QueuedTasks, 1)
+	assert.Equal(t, TaskStateRunning, snapshot.QueuedTasks[0].State)
+	assert.Len(t, snapshot.RunningExecutions, 1)
+	assert.Equal(t, task.(Identifiable).ID(), snapshot.RunningExecutions[0].TaskID)
+}
+
+func TestSimpleTaskExecutor_Snapshot_AfterShutdown(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+
+	_, err := executor.Schedule(func(ctx context.Context) {
+	}, 1*time.Hour)
+	assert.Nil(t, err)
+
+	<-executor.Shutdown()
+
+	snapshot := executor.Snapshot()
+	assert.Empty(t, snapshot.QueuedTasks)
+	assert.Empty(t, snapshot.RunningExecutions)
+}
+
+func TestSimpleTaskScheduler_Snapshot(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewSimpleTaskExecutor(NewDefaultTaskRunner()))
+
+	_, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+	}, "0 0 0 * * *", WithName("cron-task"))
+	assert.Nil(t, err)
+
+	scheduler.Pause()
+	snapshot := scheduler.Snapshot()
+
+	assert.True(t, snapshot.Paused)
+	assert.Len(t, snapshot.QueuedTasks, 1)
+	assert.Equal(t, "cron-task", snapshot.QueuedTasks[0].Name)
+	assert.Equal(t, TriggerTypeCron, snapshot.QueuedTasks[0].TriggerType)
+	assert.Equal(t, "0 0 0 * * *", snapshot.QueuedTasks[0].CronExpression)
+	assert.Equal(t, time.Duration(0), snapshot.QueuedTasks[0].Period)
+}
+
+func TestTaskState_String(t *testing.T) {
+	assert.Equal(t, "scheduled", TaskStateScheduled.String())
+	assert.Equal(t, "running", TaskStateRunning.String())
+	assert.Equal(t, "paused", TaskStatePaused.String())
+	assert.Equal(t, "cancelled", TaskStateCancelled.String())
+	assert.Equal(t, "unknown", TaskState(-1).String())
+}
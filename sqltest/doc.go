@@ -0,0 +1,3 @@
+// Package sqltest tests the SQL job store and locker of chrono against SQLite. It is a separate module,
+// so that the users of chrono do not depend on the SQLite driver.
+package sqltest
@@ -0,0 +1,37 @@
+package sqltest
+
+import (
+	"codnect.io/chrono"
+	"codnect.io/chrono/jobstoretest"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestSQLJobStore(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "jobs.db"))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	store, err := chrono.NewSQLJobStore(db, chrono.WithSQLTableName("jobs"))
+	assert.Nil(t, err)
+	assert.Nil(t, store.CreateTable())
+	assert.Nil(t, store.CreateTable())
+
+	jobstoretest.TestJobStore(t, store)
+}
+
+func TestSQLJobStore_WithDollarPlaceholders(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "jobs.db"))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	store, err := chrono.NewSQLJobStore(db, chrono.WithSQLDollarPlaceholders())
+	assert.Nil(t, err)
+	assert.Nil(t, store.CreateTable())
+
+	jobstoretest.TestJobStore(t, store)
+}
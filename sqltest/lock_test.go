@@ -0,0 +1,69 @@
+package sqltest
+
+import (
+	"codnect.io/chrono"
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLLocker(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "locks.db"))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	locker, err := chrono.NewSQLLocker(db, chrono.WithSQLLockTableName("locks"))
+	assert.Nil(t, err)
+	assert.Nil(t, locker.CreateTable())
+
+	ctx := context.Background()
+
+	lock, err := locker.Acquire(ctx, "task", time.Minute)
+	assert.Nil(t, err)
+	assert.NotNil(t, lock)
+
+	_, err = locker.Acquire(ctx, "task", time.Minute)
+	assert.ErrorIs(t, err, chrono.ErrLockNotAcquired)
+
+	otherLock, err := locker.Acquire(ctx, "other-task", time.Minute)
+	assert.Nil(t, err)
+	assert.Nil(t, otherLock.Release(ctx))
+
+	assert.Nil(t, lock.Extend(ctx, time.Minute))
+	assert.Nil(t, lock.Release(ctx))
+
+	lock, err = locker.Acquire(ctx, "task", time.Minute)
+	assert.Nil(t, err)
+	assert.Nil(t, lock.Release(ctx))
+}
+
+func TestSQLLocker_ExpiredLock(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "locks.db"))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	locker, err := chrono.NewSQLLocker(db, chrono.WithSQLLockDollarPlaceholders())
+	assert.Nil(t, err)
+	assert.Nil(t, locker.CreateTable())
+
+	ctx := context.Background()
+
+	lock, err := locker.Acquire(ctx, "task", 100*time.Millisecond)
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+
+	newLock, err := locker.Acquire(ctx, "task", time.Minute)
+	assert.Nil(t, err)
+
+	assert.EqualError(t, lock.Extend(ctx, time.Minute), "lock is not held anymore")
+	assert.Nil(t, lock.Release(ctx))
+
+	_, err = locker.Acquire(ctx, "task", time.Minute)
+	assert.ErrorIs(t, err, chrono.ErrLockNotAcquired)
+
+	assert.Nil(t, newLock.Release(ctx))
+}
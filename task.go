@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sort"
 	"sync"
 	"time"
@@ -12,9 +13,31 @@ import (
 type Task func(ctx context.Context)
 
 type SchedulerTask struct {
-	task      Task
-	startTime time.Time
-	location  *time.Location
+	task              Task
+	name              string
+	startTime         time.Time
+	location          *time.Location
+	misfirePolicy     MisfirePolicy
+	paused            bool
+	id                int
+	middlewares       []Middleware
+	trigger           Trigger
+	concurrencyPolicy ConcurrencyPolicy
+	manual            bool
+	state             *taskState
+	historySize       int
+	history           *executionHistory
+	triggerContext    *SimpleTriggerContext
+	job               *Job
+	lockTTL           time.Duration
+	lockAtLeast       time.Duration
+	lockAtLeastSet    bool
+	timeout           time.Duration
+	retryAttempts     int
+	retryDelay        time.Duration
+	followUps         []followUp
+	options           []Option
+	replaced          bool
 }
 
 func CreateSchedulerTask(task Task, options ...Option) (*SchedulerTask, error) {
@@ -23,9 +46,12 @@ func CreateSchedulerTask(task Task, options ...Option) (*SchedulerTask, error) {
 	}
 
 	runnableTask := &SchedulerTask{
-		task:      task,
-		startTime: time.Time{},
-		location:  time.Local,
+		task:        task,
+		startTime:   time.Time{},
+		location:    time.Local,
+		historySize: DefaultHistorySize,
+		lockTTL:     DefaultLockTTL,
+		options:     append([]Option{}, options...),
 	}
 
 	for _, option := range options {
@@ -90,19 +116,249 @@ func WithLocation(location string) Option {
 	}
 }
 
+func WithName(name string) Option {
+	return func(task *SchedulerTask) error {
+		if name == "" {
+			return errors.New("name cannot be empty")
+		}
+
+		task.name = name
+		return nil
+	}
+}
+
+type MisfirePolicy int
+
+const (
+	// MisfirePolicyRunOnce runs a task once as soon as possible for all the executions missed.
+	MisfirePolicyRunOnce MisfirePolicy = iota
+	// MisfirePolicyRunAll runs a task as soon as possible for each of the executions missed.
+	MisfirePolicyRunAll
+	// MisfirePolicySkip skips the executions missed and waits for the next execution time.
+	MisfirePolicySkip
+)
+
+func WithMisfirePolicy(policy MisfirePolicy) Option {
+	return func(task *SchedulerTask) error {
+		if policy < MisfirePolicyRunOnce || policy > MisfirePolicySkip {
+			return fmt.Errorf("unknown misfire policy : %d", policy)
+		}
+
+		task.misfirePolicy = policy
+		return nil
+	}
+}
+
+type ConcurrencyPolicy int
+
+const (
+	// ConcurrencyPolicyAllow allows the executions of a task to run concurrently.
+	ConcurrencyPolicyAllow ConcurrencyPolicy = iota
+	// ConcurrencyPolicyForbid skips an execution of a task if another execution of the task is still running.
+	ConcurrencyPolicyForbid
+)
+
+func WithConcurrencyPolicy(policy ConcurrencyPolicy) Option {
+	return func(task *SchedulerTask) error {
+		if policy < ConcurrencyPolicyAllow || policy > ConcurrencyPolicyForbid {
+			return fmt.Errorf("unknown concurrency policy : %d", policy)
+		}
+
+		task.concurrencyPolicy = policy
+		return nil
+	}
+}
+
+// WithHistorySize sets how many of the last executions of a task are kept in its history.
+func WithHistorySize(size int) Option {
+	return func(task *SchedulerTask) error {
+		if size < 0 {
+			return errors.New("history size cannot be negative")
+		}
+
+		task.historySize = size
+		return nil
+	}
+}
+
+// WithLockTTL sets how long the lock of a task is acquired for before it is extended.
+func WithLockTTL(ttl time.Duration) Option {
+	return func(task *SchedulerTask) error {
+		if ttl <= 0 {
+			return errors.New("lock ttl must be greater than zero")
+		}
+
+		task.lockTTL = ttl
+		return nil
+	}
+}
+
+// WithLockAtLeast sets the minimum duration the lock of a task is held for, so that the other
+// instances cannot run the same execution again after a short execution is completed. It overrides
+// DefaultLockAtLeast, and zero releases the lock as soon as the execution is completed.
+func WithLockAtLeast(duration time.Duration) Option {
+	return func(task *SchedulerTask) error {
+		if duration < 0 {
+			return errors.New("lock duration cannot be negative")
+		}
+
+		task.lockAtLeast = duration
+		task.lockAtLeastSet = true
+		return nil
+	}
+}
+
+func withID(id int) Option {
+	return func(task *SchedulerTask) error {
+		task.id = id
+		return nil
+	}
+}
+
+func withPaused(paused bool) Option {
+	return func(task *SchedulerTask) error {
+		task.paused = paused
+		return nil
+	}
+}
+
+func withTrigger(trigger Trigger) Option {
+	return func(task *SchedulerTask) error {
+		task.trigger = trigger
+		return nil
+	}
+}
+
+func withManual() Option {
+	return func(task *SchedulerTask) error {
+		task.manual = true
+		return nil
+	}
+}
+
+func withHistory(history *executionHistory) Option {
+	return func(task *SchedulerTask) error {
+		task.history = history
+		return nil
+	}
+}
+
+// withReplacedTask makes the task take the place of the task with the given id, keeping its id and history.
+func withReplacedTask(id int, history *executionHistory) Option {
+	return func(task *SchedulerTask) error {
+		task.id = id
+		task.history = history
+		task.replaced = true
+		return nil
+	}
+}
+
+func withTriggerContext(ctx *SimpleTriggerContext) Option {
+	return func(task *SchedulerTask) error {
+		task.triggerContext = ctx
+		return nil
+	}
+}
+
+func withJob(job *Job) Option {
+	return func(task *SchedulerTask) error {
+		task.job = job
+		return nil
+	}
+}
+
+func withTaskState(state *taskState) Option {
+	return func(task *SchedulerTask) error {
+		task.state = state
+		return nil
+	}
+}
+
+// taskState is shared by the scheduled and the manual executions of a task.
+type taskState struct {
+	running int
+	stateMu sync.Mutex
+}
+
+// tryStart marks an execution of the task as running unless the concurrency policy forbids it.
+func (state *taskState) tryStart(policy ConcurrencyPolicy) bool {
+	state.stateMu.Lock()
+	defer state.stateMu.Unlock()
+
+	if policy == ConcurrencyPolicyForbid && state.running > 0 {
+		return false
+	}
+
+	state.running++
+	return true
+}
+
+func (state *taskState) done() {
+	state.stateMu.Lock()
+	defer state.stateMu.Unlock()
+	state.running--
+}
+
 type ScheduledTask interface {
 	Cancel()
 	IsCancelled() bool
 }
 
+// Identifiable is implemented by the scheduled tasks, which have an id and a name.
+type Identifiable interface {
+	ID() int
+	Name() string
+}
+
+// Pausable is implemented by the scheduled tasks, and the executors and schedulers which can be paused.
+type Pausable interface {
+	Pause()
+	Resume()
+	IsPaused() bool
+}
+
+// Triggerable is implemented by the scheduled tasks which can be triggered manually.
+type Triggerable interface {
+	TriggerNow() error
+}
+
+// HistoryProvider is implemented by the scheduled tasks keeping the history of their executions.
+type HistoryProvider interface {
+	History() []ExecutionRecord
+}
+
+// taskID returns the id of the scheduled task, or zero if it does not have any.
+func taskID(task ScheduledTask) int {
+	if identifiable, ok := task.(Identifiable); ok {
+		return identifiable.ID()
+	}
+
+	return 0
+}
+
 type ScheduledRunnableTask struct {
-	id          int
-	task        Task
-	taskMu      sync.RWMutex
-	triggerTime time.Time
-	period      time.Duration
-	fixedRate   bool
-	cancelled   bool
+	id                 int
+	task               Task
+	taskMu             sync.RWMutex
+	triggerTime        time.Time
+	period             time.Duration
+	fixedRate          bool
+	cancelled          bool
+	paused             bool
+	misfired           bool
+	skipped            bool
+	misfirePolicy      MisfirePolicy
+	name               string
+	pendingTriggerTime time.Time
+	trigger            Trigger
+	concurrencyPolicy  ConcurrencyPolicy
+	manual             bool
+	state              *taskState
+	history            *executionHistory
+	lockTTL            time.Duration
+	lockAtLeast        time.Duration
+	lockAtLeastSet     bool
+	executor           *SimpleTaskExecutor
 }
 
 func CreateScheduledRunnableTask(id int, task Task, triggerTime time.Time, period time.Duration, fixedRate bool) (*ScheduledRunnableTask, error) {
@@ -120,10 +376,23 @@ func CreateScheduledRunnableTask(id int, task Task, triggerTime time.Time, perio
 		triggerTime: triggerTime,
 		period:      period,
 		fixedRate:   fixedRate,
+		state:       &taskState{},
+		history:     newExecutionHistory(DefaultHistorySize),
 	}, nil
 }
 
 func (scheduledRunnableTask *ScheduledRunnableTask) Cancel() {
+	scheduledRunnableTask.taskMu.Lock()
+	alreadyCancelled := scheduledRunnableTask.cancelled
+	scheduledRunnableTask.cancelled = true
+	scheduledRunnableTask.taskMu.Unlock()
+
+	if !alreadyCancelled {
+		scheduledRunnableTask.publishEvent(taskEventCancel, scheduledRunnableTask.event(time.Time{}))
+	}
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) cancel() {
 	scheduledRunnableTask.taskMu.Lock()
 	defer scheduledRunnableTask.taskMu.Unlock()
 	scheduledRunnableTask.cancelled = true
@@ -135,18 +404,241 @@ func (scheduledRunnableTask *ScheduledRunnableTask) IsCancelled() bool {
 	return scheduledRunnableTask.cancelled
 }
 
+func (scheduledRunnableTask *ScheduledRunnableTask) Pause() {
+	scheduledRunnableTask.taskMu.Lock()
+	defer scheduledRunnableTask.taskMu.Unlock()
+	scheduledRunnableTask.paused = true
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) Resume() {
+	scheduledRunnableTask.taskMu.Lock()
+
+	if !scheduledRunnableTask.paused || scheduledRunnableTask.cancelled {
+		scheduledRunnableTask.taskMu.Unlock()
+		return
+	}
+
+	scheduledRunnableTask.paused = false
+	scheduledRunnableTask.taskMu.Unlock()
+
+	if scheduledRunnableTask.executor != nil {
+		scheduledRunnableTask.executor.resumeTask(scheduledRunnableTask)
+	}
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) IsPaused() bool {
+	scheduledRunnableTask.taskMu.RLock()
+	defer scheduledRunnableTask.taskMu.RUnlock()
+	return scheduledRunnableTask.paused
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) Reschedule(delay time.Duration, period time.Duration) error {
+	if scheduledRunnableTask.IsCancelled() {
+		return errors.New("task cannot be rescheduled because it is already cancelled")
+	}
+
+	if scheduledRunnableTask.executor == nil {
+		return errors.New("task cannot be rescheduled because it has not been scheduled by an executor")
+	}
+
+	if period < 0 {
+		period = 0
+	}
+
+	if scheduledRunnableTask.isPeriodic() != (period != 0) {
+		return errors.New("period of a periodic task must be positive and period of a one-shot task must be zero")
+	}
+
+	return scheduledRunnableTask.executor.rescheduleTask(scheduledRunnableTask, delay, period)
+}
+
+// TriggerNow submits an extra execution of the task to be run immediately without changing its schedule.
+func (scheduledRunnableTask *ScheduledRunnableTask) TriggerNow() error {
+	if scheduledRunnableTask.IsCancelled() {
+		return errors.New("task cannot be triggered because it is already cancelled")
+	}
+
+	if scheduledRunnableTask.executor == nil {
+		return errors.New("task cannot be triggered because it has not been scheduled by an executor")
+	}
+
+	options := []Option{
+		withID(scheduledRunnableTask.id),
+		withManual(),
+		withTaskState(scheduledRunnableTask.state),
+		withHistory(scheduledRunnableTask.history),
+		WithConcurrencyPolicy(scheduledRunnableTask.concurrencyPolicy),
+		WithLockTTL(scheduledRunnableTask.lockTTL),
+	}
+
+	if scheduledRunnableTask.lockAtLeastSet {
+		options = append(options, WithLockAtLeast(scheduledRunnableTask.lockAtLeast))
+	}
+
+	if scheduledRunnableTask.name != "" {
+		options = append(options, WithName(scheduledRunnableTask.name))
+	}
+
+	_, err := scheduledRunnableTask.executor.schedule(scheduledRunnableTask.task, 0, 0, false, options...)
+	return err
+}
+
+// History returns the last executions of the task from the oldest to the newest.
+func (scheduledRunnableTask *ScheduledRunnableTask) History() []ExecutionRecord {
+	return scheduledRunnableTask.history.list()
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) ID() int {
+	return scheduledRunnableTask.id
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) Name() string {
+	return scheduledRunnableTask.name
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) event(scheduledTime time.Time) TaskEvent {
+	return TaskEvent{
+		TaskID:        scheduledRunnableTask.id,
+		TaskName:      scheduledRunnableTask.name,
+		ScheduledTime: scheduledTime,
+		Manual:        scheduledRunnableTask.manual,
+	}
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) spanInfo(execution *Execution) SpanInfo {
+	triggerType, cronExpression := scheduledRunnableTask.describeTrigger()
+
+	return SpanInfo{
+		TaskID:         scheduledRunnableTask.id,
+		TaskName:       scheduledRunnableTask.name,
+		TriggerType:    triggerType,
+		CronExpression: cronExpression,
+		ScheduledTime:  execution.ScheduledTime(),
+		Attempt:        execution.Attempt(),
+	}
+}
+
+// describeTrigger returns the trigger type of the task, and the cron expression if it is a cron task.
+func (scheduledRunnableTask *ScheduledRunnableTask) describeTrigger() (string, string) {
+	if scheduledRunnableTask.manual {
+		return TriggerTypeManual, ""
+	}
+
+	switch trigger := scheduledRunnableTask.trigger.(type) {
+	case *CronTrigger:
+		return TriggerTypeCron, trigger.Expression()
+	case *EventTrigger:
+		return TriggerTypeEvent, ""
+	case nil:
+		if !scheduledRunnableTask.isPeriodic() {
+			return TriggerTypeOneShot, ""
+		} else if scheduledRunnableTask.isFixedRate() {
+			return TriggerTypeFixedRate, ""
+		}
+
+		return TriggerTypeFixedDelay, ""
+	}
+
+	return TriggerTypeCustom, ""
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) publishEvent(eventType taskEventType, event TaskEvent) {
+	if scheduledRunnableTask.executor != nil {
+		scheduledRunnableTask.executor.publishEvent(eventType, event)
+	}
+}
+
 func (scheduledRunnableTask *ScheduledRunnableTask) getDelay() time.Duration {
 	return scheduledRunnableTask.triggerTime.Sub(time.Now())
 }
 
+func (scheduledRunnableTask *ScheduledRunnableTask) getTriggerTime() time.Time {
+	scheduledRunnableTask.taskMu.RLock()
+	defer scheduledRunnableTask.taskMu.RUnlock()
+	return scheduledRunnableTask.triggerTime
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) getPeriod() time.Duration {
+	scheduledRunnableTask.taskMu.RLock()
+	defer scheduledRunnableTask.taskMu.RUnlock()
+	return scheduledRunnableTask.period
+}
+
 func (scheduledRunnableTask *ScheduledRunnableTask) isPeriodic() bool {
-	return scheduledRunnableTask.period != 0
+	return scheduledRunnableTask.getPeriod() != 0
 }
 
 func (scheduledRunnableTask *ScheduledRunnableTask) isFixedRate() bool {
+	scheduledRunnableTask.taskMu.RLock()
+	defer scheduledRunnableTask.taskMu.RUnlock()
 	return scheduledRunnableTask.fixedRate
 }
 
+func (scheduledRunnableTask *ScheduledRunnableTask) isMisfired() bool {
+	scheduledRunnableTask.taskMu.RLock()
+	defer scheduledRunnableTask.taskMu.RUnlock()
+	return scheduledRunnableTask.misfired
+}
+
+// misfire applies the misfire policy of the task whose trigger time has passed, or skips the missed executions
+// whatever its policy is if skip is true. It returns false if the task must not be executed anymore.
+func (scheduledRunnableTask *ScheduledRunnableTask) misfire(now time.Time, skip bool) bool {
+	scheduledRunnableTask.taskMu.Lock()
+	defer scheduledRunnableTask.taskMu.Unlock()
+
+	scheduledRunnableTask.misfired = true
+	period := scheduledRunnableTask.period
+	policy := scheduledRunnableTask.misfirePolicy
+
+	if skip {
+		// the executions of a trigger task are skipped by the trigger task itself, which schedules its next execution
+		if scheduledRunnableTask.trigger != nil {
+			scheduledRunnableTask.skipped = true
+			return true
+		}
+
+		policy = MisfirePolicySkip
+	}
+
+	switch policy {
+	case MisfirePolicySkip:
+		if period == 0 {
+			scheduledRunnableTask.cancelled = true
+			return false
+		}
+
+		if scheduledRunnableTask.fixedRate {
+			missed := now.Sub(scheduledRunnableTask.triggerTime)/period + 1
+			scheduledRunnableTask.triggerTime = scheduledRunnableTask.triggerTime.Add(missed * period)
+		} else {
+			scheduledRunnableTask.triggerTime = now.Add(period)
+		}
+	case MisfirePolicyRunOnce:
+		if period != 0 && scheduledRunnableTask.fixedRate {
+			missed := now.Sub(scheduledRunnableTask.triggerTime) / period
+			scheduledRunnableTask.triggerTime = scheduledRunnableTask.triggerTime.Add(missed * period)
+		}
+	}
+
+	return true
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) isSkipped() bool {
+	scheduledRunnableTask.taskMu.RLock()
+	defer scheduledRunnableTask.taskMu.RUnlock()
+	return scheduledRunnableTask.skipped
+}
+
+func (scheduledRunnableTask *ScheduledRunnableTask) applyPendingTriggerTime() {
+	scheduledRunnableTask.taskMu.Lock()
+	defer scheduledRunnableTask.taskMu.Unlock()
+
+	if !scheduledRunnableTask.pendingTriggerTime.IsZero() {
+		scheduledRunnableTask.triggerTime = scheduledRunnableTask.pendingTriggerTime
+		scheduledRunnableTask.pendingTriggerTime = time.Time{}
+	}
+}
+
 type ScheduledTaskQueue []*ScheduledRunnableTask
 
 func (queue ScheduledTaskQueue) Len() int {
@@ -173,11 +665,26 @@ type TriggerTask struct {
 	triggerContextMu     sync.RWMutex
 	trigger              Trigger
 	nextTriggerTime      time.Time
+	recovering           bool
+	running              bool
+	paused               bool
+	id                   int
+	name                 string
+	misfirePolicy        MisfirePolicy
+	concurrencyPolicy    ConcurrencyPolicy
+	state                *taskState
+	history              *executionHistory
+	lockTTL              time.Duration
+	lockAtLeast          time.Duration
+	lockAtLeastSet       bool
+	replaced             bool
 }
 
-func CreateTriggerTask(task Task, executor TaskExecutor, trigger Trigger) (*TriggerTask, error) {
-	if task == nil {
-		return nil, errors.New("task cannot be nil")
+func CreateTriggerTask(task Task, executor TaskExecutor, trigger Trigger, options ...Option) (*TriggerTask, error) {
+	schedulerTask, err := CreateSchedulerTask(task, options...)
+
+	if err != nil {
+		return nil, err
 	}
 
 	if executor == nil {
@@ -188,18 +695,55 @@ func CreateTriggerTask(task Task, executor TaskExecutor, trigger Trigger) (*Trig
 		return nil, errors.New("trigger cannot be nil")
 	}
 
+	triggerContext := schedulerTask.triggerContext
+
+	if triggerContext == nil {
+		triggerContext = NewSimpleTriggerContext()
+	}
+
+	history := schedulerTask.history
+
+	if history == nil {
+		history = newExecutionHistory(schedulerTask.historySize)
+	}
+
 	return &TriggerTask{
-		task:           task,
-		executor:       executor,
-		triggerContext: NewSimpleTriggerContext(),
-		trigger:        trigger,
+		id:                schedulerTask.id,
+		task:              task,
+		executor:          executor,
+		triggerContext:    triggerContext,
+		trigger:           trigger,
+		paused:            schedulerTask.paused,
+		name:              schedulerTask.name,
+		misfirePolicy:     schedulerTask.misfirePolicy,
+		concurrencyPolicy: schedulerTask.concurrencyPolicy,
+		state:             &taskState{},
+		history:           history,
+		lockTTL:           schedulerTask.lockTTL,
+		lockAtLeast:       schedulerTask.lockAtLeast,
+		lockAtLeastSet:    schedulerTask.lockAtLeastSet,
+		replaced:          schedulerTask.replaced,
 	}, nil
 }
 
+func (task *TriggerTask) ID() int {
+	task.triggerContextMu.Lock()
+	defer task.triggerContextMu.Unlock()
+	return task.id
+}
+
+func (task *TriggerTask) Name() string {
+	return task.name
+}
+
 func (task *TriggerTask) Cancel() {
 	task.triggerContextMu.Lock()
 	defer task.triggerContextMu.Unlock()
 	task.currentScheduledTask.Cancel()
+
+	if eventTrigger, ok := task.trigger.(*EventTrigger); ok {
+		eventTrigger.stop()
+	}
 }
 
 func (task *TriggerTask) IsCancelled() bool {
@@ -208,39 +752,353 @@ func (task *TriggerTask) IsCancelled() bool {
 	return task.currentScheduledTask.IsCancelled()
 }
 
+func (task *TriggerTask) Pause() {
+	task.triggerContextMu.Lock()
+	defer task.triggerContextMu.Unlock()
+	task.paused = true
+
+	if task.currentScheduledTask != nil {
+		task.currentScheduledTask.Pause()
+	}
+}
+
+func (task *TriggerTask) Resume() {
+	task.triggerContextMu.Lock()
+	task.paused = false
+	currentScheduledTask := task.currentScheduledTask
+	task.triggerContextMu.Unlock()
+
+	if currentScheduledTask != nil {
+		currentScheduledTask.Resume()
+	}
+}
+
+func (task *TriggerTask) IsPaused() bool {
+	task.triggerContextMu.Lock()
+	defer task.triggerContextMu.Unlock()
+	return task.paused
+}
+
 func (task *TriggerTask) Schedule() (ScheduledTask, error) {
 	task.triggerContextMu.Lock()
 	defer task.triggerContextMu.Unlock()
 
 	task.nextTriggerTime = task.trigger.NextExecutionTime(task.triggerContext)
 
+	if task.currentScheduledTask == nil {
+		if missedTime := task.firstMissedTime(); !missedTime.IsZero() {
+			loggerOf(task.executor).Warn("task missed executions while the scheduler was not running",
+				taskLogAttrs(task.id, task.name, slog.Time("missed_time", missedTime))...)
+			task.nextTriggerTime = missedTime
+			task.recovering = true
+		}
+	}
+
 	if task.nextTriggerTime.IsZero() {
+		loggerOf(task.executor).Warn("task not scheduled because trigger returned no execution time",
+			taskLogAttrs(task.id, task.name)...)
 		return nil, errors.New("could not schedule task because of the fact that schedule time is zero")
 	}
 
 	initialDelay := task.nextTriggerTime.Sub(time.Now())
 
-	currentScheduledTask, err := task.executor.Schedule(task.Run, initialDelay)
+	options := []Option{withID(task.id), withPaused(task.paused), withTrigger(task.trigger), withHistory(task.history)}
+
+	// the first execution of a task replacing another one is announced as a new task
+	if task.currentScheduledTask == nil && task.replaced {
+		options = append(options, withReplacedTask(task.id, task.history))
+	}
+
+	if task.name != "" {
+		options = append(options, WithName(task.name))
+	}
+
+	currentScheduledTask, err := scheduleTask(task.executor, task.Run, initialDelay, 0, false, options...)
 
 	if err != nil {
+		loggerOf(task.executor).Error("task could not be scheduled", taskLogAttrs(task.id, task.name,
+			slog.Time("trigger_time", task.nextTriggerTime),
+			slog.Any("error", err))...)
 		return nil, err
 	}
 
 	task.currentScheduledTask = currentScheduledTask.(*ScheduledRunnableTask)
+	task.id = task.currentScheduledTask.id
+
+	if eventTrigger, ok := task.trigger.(*EventTrigger); ok {
+		eventTrigger.start(task.wake)
+	}
+
 	return task, nil
 }
 
-func (task *TriggerTask) Run(ctx context.Context) {
+// wake reschedules the current execution of the task once an event changes the next execution time of its trigger.
+func (task *TriggerTask) wake() {
+	task.triggerContextMu.Lock()
+	defer task.triggerContextMu.Unlock()
+
+	if task.currentScheduledTask == nil || task.running {
+		return
+	}
+
+	if task.currentScheduledTask.IsCancelled() {
+		if eventTrigger, ok := task.trigger.(*EventTrigger); ok {
+			eventTrigger.stop()
+		}
+
+		return
+	}
+
+	nextTriggerTime := task.trigger.NextExecutionTime(task.triggerContext)
+
+	if nextTriggerTime.Equal(task.nextTriggerTime) {
+		return
+	}
+
+	// the current execution fails to be rescheduled if it has just been started, and then the event
+	// is handled while the next execution is being scheduled
+	if err := task.currentScheduledTask.Reschedule(nextTriggerTime.Sub(time.Now()), 0); err == nil {
+		task.nextTriggerTime = nextTriggerTime
+	}
+}
+
+func (task *TriggerTask) Reschedule(trigger Trigger) error {
+	if trigger == nil {
+		return errors.New("trigger cannot be nil")
+	}
+
 	task.triggerContextMu.Lock()
+	defer task.triggerContextMu.Unlock()
+
+	if task.currentScheduledTask == nil || task.currentScheduledTask.IsCancelled() {
+		return errors.New("task cannot be rescheduled because it is already cancelled")
+	}
+
+	nextTriggerTime := trigger.NextExecutionTime(task.triggerContext)
+
+	if nextTriggerTime.IsZero() {
+		return errors.New("could not reschedule task because of the fact that schedule time is zero")
+	}
+
+	if eventTrigger, ok := task.trigger.(*EventTrigger); ok && task.trigger != trigger {
+		eventTrigger.stop()
+	}
+
+	task.trigger = trigger
+
+	if eventTrigger, ok := trigger.(*EventTrigger); ok {
+		eventTrigger.start(task.wake)
+	}
+
+	// the current execution may have just been started, in that case the new trigger
+	// will be used while the next execution is being scheduled
+	if !task.running {
+		if err := task.currentScheduledTask.Reschedule(nextTriggerTime.Sub(time.Now()), 0); err == nil {
+			task.nextTriggerTime = nextTriggerTime
+			return nil
+		}
+	}
+
+	task.currentScheduledTask.publishEvent(taskEventReschedule, TaskEvent{
+		TaskID:        task.id,
+		TaskName:      task.name,
+		ScheduledTime: nextTriggerTime,
+	})
+
+	return nil
+}
+
+// History returns the last executions of the task from the oldest to the newest.
+func (task *TriggerTask) History() []ExecutionRecord {
+	return task.history.list()
+}
+
+// TriggerNow submits an extra execution of the task to be run immediately. The manual executions
+// do not change the schedule of the task.
+func (task *TriggerTask) TriggerNow() error {
+	if task.IsCancelled() {
+		return errors.New("task cannot be triggered because it is already cancelled")
+	}
+
+	options := []Option{withID(task.ID()), withManual(), withHistory(task.history), WithLockTTL(task.lockTTL)}
+
+	if task.lockAtLeastSet {
+		options = append(options, WithLockAtLeast(task.lockAtLeast))
+	}
+
+	if task.name != "" {
+		options = append(options, WithName(task.name))
+	}
+
+	_, err := scheduleTask(task.executor, task.runManually, 0, 0, false, options...)
+	return err
+}
+
+func (task *TriggerTask) runManually(ctx context.Context) {
+	execution, _ := ExecutionFromContext(ctx)
+
+	if !task.state.tryStart(task.concurrencyPolicy) {
+		if execution != nil {
+			execution.skip(ErrConcurrentExecution)
+		}
+
+		return
+	}
+
+	defer task.state.done()
 
 	executionTime := time.Now()
-	task.task(ctx)
+	err := runTask(ctx, task.task)
 	completionTime := time.Now()
 
-	task.triggerContext.Update(completionTime, executionTime, task.nextTriggerTime)
+	task.fail(execution, err)
+
+	task.triggerContextMu.Lock()
+	task.triggerContext.UpdateManual(completionTime, executionTime)
+	task.triggerContextMu.Unlock()
+}
+
+func (task *TriggerTask) Run(ctx context.Context) {
+	execution, _ := ExecutionFromContext(ctx)
+
+	if !task.state.tryStart(task.concurrencyPolicy) {
+		if execution != nil {
+			execution.skip(ErrConcurrentExecution)
+		}
+
+		task.scheduleNext()
+		return
+	}
+
+	defer task.state.done()
+
+	release, err := acquireTaskLock(ctx, task.executor, task.ID(), task.name, task.lockTTL,
+		lockHoldDuration(task.lockAtLeast, task.lockAtLeastSet, task.interval()))
+
+	if err != nil {
+		if execution != nil {
+			execution.skip(err)
+		}
+
+		task.scheduleNext()
+		return
+	}
+
+	defer release()
+
+	task.triggerContextMu.Lock()
+	task.running = true
+	triggerTimes := task.triggerTimesToRun()
+
+	task.triggerContextMu.Unlock()
+
+	if len(triggerTimes) == 0 && execution != nil {
+		execution.skip(ErrExecutionMisfired)
+	}
+
+	for _, triggerTime := range triggerTimes {
+		executionTime := time.Now()
+		err := runTask(ctx, task.task)
+		completionTime := time.Now()
+
+		task.fail(execution, err)
+
+		task.triggerContextMu.Lock()
+		task.triggerContext.Update(completionTime, executionTime, triggerTime)
+		task.triggerContextMu.Unlock()
+	}
+
+	task.triggerContextMu.Lock()
+	task.running = false
 	task.triggerContextMu.Unlock()
 
-	if !task.IsCancelled() {
-		task.Schedule()
+	task.scheduleNext()
+}
+
+// interval returns the duration between the current and the next execution times of a cron trigger,
+// or zero if it is not known.
+func (task *TriggerTask) interval() time.Duration {
+	task.triggerContextMu.Lock()
+	defer task.triggerContextMu.Unlock()
+
+	cronTrigger, ok := task.trigger.(*CronTrigger)
+
+	if !ok {
+		return 0
+	}
+
+	nextTime := cronTrigger.NextExecutionTimeAfter(task.nextTriggerTime)
+
+	if nextTime.IsZero() {
+		return 0
+	}
+
+	return nextTime.Sub(task.nextTriggerTime)
+}
+
+func (task *TriggerTask) fail(execution *Execution, err error) {
+	if err == nil {
+		return
+	}
+
+	if execution != nil {
+		execution.Fail(err)
+	} else {
+		loggerOf(task.executor).Error("task failed", taskLogAttrs(task.id, task.name, slog.Any("error", err))...)
+	}
+}
+
+func (task *TriggerTask) scheduleNext() {
+	if task.IsCancelled() {
+		return
+	}
+
+	if _, err := task.Schedule(); err != nil {
+		loggerOf(task.executor).Error("task stopped because its next execution could not be scheduled",
+			taskLogAttrs(task.id, task.name, slog.Any("error", err))...)
+	}
+}
+
+// firstMissedTime returns the first execution time missed since the last triggered execution time
+// of a restored trigger context, or zero time if there is not any.
+func (task *TriggerTask) firstMissedTime() time.Time {
+	cronTrigger, ok := task.trigger.(*CronTrigger)
+	lastTriggeredTime := task.triggerContext.LastTriggeredExecutionTime()
+
+	if !ok || lastTriggeredTime.IsZero() {
+		return time.Time{}
+	}
+
+	missedTime := cronTrigger.NextExecutionTimeAfter(lastTriggeredTime)
+
+	if missedTime.IsZero() || !missedTime.Before(time.Now()) {
+		return time.Time{}
+	}
+
+	return missedTime
+}
+
+func (task *TriggerTask) triggerTimesToRun() []time.Time {
+	recovering := task.recovering
+	task.recovering = false
+
+	if !recovering && (task.currentScheduledTask == nil || !task.currentScheduledTask.isMisfired()) {
+		return []time.Time{task.nextTriggerTime}
+	}
+
+	// the executions missed in standby are skipped whatever the misfire policy is
+	if task.currentScheduledTask != nil && task.currentScheduledTask.isSkipped() {
+		return nil
+	}
+
+	switch task.misfirePolicy {
+	case MisfirePolicySkip:
+		return nil
+	case MisfirePolicyRunAll:
+		if cronTrigger, ok := task.trigger.(*CronTrigger); ok {
+			return cronTrigger.executionTimesBetween(task.nextTriggerTime, time.Now())
+		}
 	}
+
+	return []time.Time{task.nextTriggerTime}
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -73,27 +74,27 @@ type scheduledExecutorMock struct {
 	mock.Mock
 }
 
-func (executor scheduledExecutorMock) Schedule(task Task, delay time.Duration) (ScheduledTask, error) {
+func (executor *scheduledExecutorMock) Schedule(task Task, delay time.Duration) (ScheduledTask, error) {
 	result := executor.Called(task, delay)
 	return result.Get(0).(ScheduledTask), result.Error(1)
 }
 
-func (executor scheduledExecutorMock) ScheduleWithFixedDelay(task Task, initialDelay time.Duration, delay time.Duration) (ScheduledTask, error) {
+func (executor *scheduledExecutorMock) ScheduleWithFixedDelay(task Task, initialDelay time.Duration, delay time.Duration) (ScheduledTask, error) {
 	result := executor.Called(task, initialDelay, delay)
 	return result.Get(0).(ScheduledTask), result.Error(1)
 }
 
-func (executor scheduledExecutorMock) ScheduleAtFixedRate(task Task, initialDelay time.Duration, period time.Duration) (ScheduledTask, error) {
+func (executor *scheduledExecutorMock) ScheduleAtFixedRate(task Task, initialDelay time.Duration, period time.Duration) (ScheduledTask, error) {
 	result := executor.Called(task, initialDelay, period)
 	return result.Get(0).(ScheduledTask), result.Error(1)
 }
 
-func (executor scheduledExecutorMock) IsShutdown() bool {
+func (executor *scheduledExecutorMock) IsShutdown() bool {
 	result := executor.Called()
 	return result.Bool(0)
 }
 
-func (executor scheduledExecutorMock) Shutdown() chan bool {
+func (executor *scheduledExecutorMock) Shutdown() chan bool {
 	result := executor.Called()
 	return result.Get(0).(chan bool)
 }
@@ -112,3 +113,135 @@ func TestTriggerTask_ScheduleWithError(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+func TestTriggerTask_Reschedule(t *testing.T) {
+	trigger, err := CreateCronTrigger("0 0 0 1 1 *", time.Local)
+	assert.Nil(t, err)
+
+	var counter int32
+
+	task, _ := CreateTriggerTask(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, NewDefaultTaskExecutor(), trigger)
+
+	_, err = task.Schedule()
+	assert.Nil(t, err)
+
+	assert.Error(t, task.Reschedule(nil))
+
+	trigger, err = CreateCronTrigger("* * * * * *", time.Local)
+	assert.Nil(t, err)
+	assert.Nil(t, task.Reschedule(trigger))
+
+	<-time.After(2*time.Second + 100*time.Millisecond)
+	task.Cancel()
+
+	assert.True(t, counter >= 1,
+		"number of scheduled task execution must be at least 1, actual: %d", counter)
+	assert.False(t, task.triggerContext.LastCompletionTime().IsZero())
+	assert.Error(t, task.Reschedule(trigger))
+}
+
+func TestTriggerTask_TriggerNow(t *testing.T) {
+	trigger, err := CreateCronTrigger("0 0 0 1 1 *", time.Local)
+	assert.Nil(t, err)
+
+	var counter int32
+
+	task, _ := CreateTriggerTask(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+	}, NewDefaultTaskExecutor(), trigger)
+
+	_, err = task.Schedule()
+	assert.Nil(t, err)
+	nextTriggerTime := task.nextTriggerTime
+
+	assert.Nil(t, task.TriggerNow())
+	<-time.After(100 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+
+	task.triggerContextMu.RLock()
+	assert.Equal(t, nextTriggerTime, task.nextTriggerTime)
+	assert.True(t, task.triggerContext.LastCompletionTime().IsZero())
+	assert.False(t, task.triggerContext.LastManualExecutionTime().IsZero())
+	assert.False(t, task.triggerContext.LastManualCompletionTime().IsZero())
+	task.triggerContextMu.RUnlock()
+
+	task.Cancel()
+	assert.Error(t, task.TriggerNow())
+}
+
+func TestTriggerTask_ConcurrencyPolicyForbid(t *testing.T) {
+	trigger, err := CreateCronTrigger("* * * * * *", time.Local)
+	assert.Nil(t, err)
+
+	var counter int32
+
+	task, _ := CreateTriggerTask(func(ctx context.Context) {
+		atomic.AddInt32(&counter, 1)
+		<-time.After(1500 * time.Millisecond)
+	}, NewDefaultTaskExecutor(), trigger, WithConcurrencyPolicy(ConcurrencyPolicyForbid))
+
+	_, err = task.Schedule()
+	assert.Nil(t, err)
+
+	assert.Nil(t, task.TriggerNow())
+	<-time.After(1200 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+
+	<-time.After(1500 * time.Millisecond)
+	task.Cancel()
+
+	assert.True(t, atomic.LoadInt32(&counter) >= 2,
+		"the task must keep being scheduled after an execution is skipped, actual: %d", counter)
+}
+
+func TestNewSchedulerTask_WithInvalidMisfirePolicy(t *testing.T) {
+	_, err := CreateSchedulerTask(func(ctx context.Context) {
+
+	}, WithMisfirePolicy(MisfirePolicy(-1)))
+	assert.Error(t, err)
+}
+
+func TestTriggerTask_PauseAndResume(t *testing.T) {
+	testCases := []struct {
+		policy  MisfirePolicy
+		atLeast int32
+		atMost  int32
+	}{
+		{MisfirePolicyRunOnce, 1, 2},
+		{MisfirePolicyRunAll, 2, 5},
+		{MisfirePolicySkip, 0, 1},
+	}
+
+	for _, testCase := range testCases {
+		trigger, err := CreateCronTrigger("* * * * * *", time.Local)
+		assert.Nil(t, err)
+
+		var counter int32
+
+		task, _ := CreateTriggerTask(func(ctx context.Context) {
+			atomic.AddInt32(&counter, 1)
+		}, NewDefaultTaskExecutor(), trigger, WithMisfirePolicy(testCase.policy))
+
+		_, err = task.Schedule()
+		assert.Nil(t, err)
+
+		task.Pause()
+		assert.True(t, task.IsPaused())
+
+		<-time.After(3 * time.Second)
+		task.Resume()
+		assert.False(t, task.IsPaused())
+
+		<-time.After(100 * time.Millisecond)
+		task.Cancel()
+
+		actual := atomic.LoadInt32(&counter)
+		assert.True(t, actual >= testCase.atLeast && actual <= testCase.atMost,
+			"number of scheduled task execution for misfire policy %d must be between %d and %d, actual: %d",
+			testCase.policy, testCase.atLeast, testCase.atMost, actual)
+	}
+}
@@ -0,0 +1,93 @@
+package chrono
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	TriggerTypeOneShot    = "one-shot"
+	TriggerTypeFixedDelay = "fixed-delay"
+	TriggerTypeFixedRate  = "fixed-rate"
+	TriggerTypeCron       = "cron"
+	TriggerTypeEvent      = "event"
+	TriggerTypeCustom     = "custom"
+	TriggerTypeManual     = "manual"
+)
+
+// SpanInfo describes the execution a span is created for.
+type SpanInfo struct {
+	TaskID         int
+	TaskName       string
+	TriggerType    string
+	CronExpression string
+	ScheduledTime  time.Time
+	// Attempt is the attempt the span is created for. Each attempt of a retried execution has its own span.
+	Attempt int
+}
+
+// Tracer creates a span around each execution of a task. The context returned by Start
+// is passed to the task, so the span can be used as the parent of the spans created by the task.
+type Tracer interface {
+	Start(ctx context.Context, info SpanInfo) (context.Context, Span)
+}
+
+type Span interface {
+	RecordError(err error)
+	End()
+}
+
+type spanContextKey struct{}
+
+// SpanFromContext returns the span of the current execution.
+func SpanFromContext(ctx context.Context) (Span, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	span, ok := ctx.Value(spanContextKey{}).(Span)
+	return span, ok
+}
+
+type noopTracer struct {
+}
+
+func (tracer noopTracer) Start(ctx context.Context, info SpanInfo) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct {
+}
+
+func (span noopSpan) RecordError(err error) {
+}
+
+func (span noopSpan) End() {
+}
+
+type tracerHolder struct {
+	value atomic.Value
+}
+
+type storedTracer struct {
+	tracer Tracer
+}
+
+func (holder *tracerHolder) set(tracer Tracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+
+	holder.value.Store(storedTracer{tracer})
+}
+
+func (holder *tracerHolder) get() Tracer {
+	stored, ok := holder.value.Load().(storedTracer)
+
+	if !ok {
+		return noopTracer{}
+	}
+
+	return stored.tracer
+}
@@ -0,0 +1,176 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingSpan struct {
+	info   SpanInfo
+	err    error
+	ended  bool
+	spanMu sync.Mutex
+}
+
+func (span *recordingSpan) RecordError(err error) {
+	span.spanMu.Lock()
+	defer span.spanMu.Unlock()
+	span.err = err
+}
+
+func (span *recordingSpan) End() {
+	span.spanMu.Lock()
+	defer span.spanMu.Unlock()
+	span.ended = true
+}
+
+func (span *recordingSpan) getErr() error {
+	span.spanMu.Lock()
+	defer span.spanMu.Unlock()
+	return span.err
+}
+
+func (span *recordingSpan) isEnded() bool {
+	span.spanMu.Lock()
+	defer span.spanMu.Unlock()
+	return span.ended
+}
+
+type recordingTracer struct {
+	spans   []*recordingSpan
+	spansMu sync.Mutex
+}
+
+func (tracer *recordingTracer) Start(ctx context.Context, info SpanInfo) (context.Context, Span) {
+	tracer.spansMu.Lock()
+	defer tracer.spansMu.Unlock()
+
+	span := &recordingSpan{info: info}
+	tracer.spans = append(tracer.spans, span)
+	return ctx, span
+}
+
+func (tracer *recordingTracer) get() []*recordingSpan {
+	tracer.spansMu.Lock()
+	defer tracer.spansMu.Unlock()
+	return append([]*recordingSpan{}, tracer.spans...)
+}
+
+func TestSimpleTaskExecutor_SetTracer(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	tracer := &recordingTracer{}
+	executor.SetTracer(tracer)
+
+	spansInContext := make(chan Span, 1)
+
+	_, err := executor.schedule(func(ctx context.Context) {
+		span, _ := SpanFromContext(ctx)
+		spansInContext <- span
+		panic("test panic")
+	}, 0, 0, false, WithName("one-shot-task"))
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+
+	spans := tracer.get()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, spans[0], <-spansInContext)
+	assert.Equal(t, "one-shot-task", spans[0].info.TaskName)
+	assert.Equal(t, TriggerTypeOneShot, spans[0].info.TriggerType)
+	assert.Equal(t, 1, spans[0].info.Attempt)
+	assert.False(t, spans[0].info.ScheduledTime.IsZero())
+	assert.Equal(t, "task panicked : test panic", spans[0].getErr().Error())
+	assert.True(t, spans[0].isEnded())
+}
+
+func TestSimpleTaskExecutor_SetTracer_TriggerType(t *testing.T) {
+	executor := NewSimpleTaskExecutor(NewDefaultTaskRunner())
+	tracer := &recordingTracer{}
+	executor.SetTracer(tracer)
+
+	fixedDelayTask, err := executor.ScheduleWithFixedDelay(func(ctx context.Context) {
+	}, 0, 1*time.Second)
+	assert.Nil(t, err)
+
+	fixedRateTask, err := executor.ScheduleAtFixedRate(func(ctx context.Context) {
+	}, 0, 1*time.Second)
+	assert.Nil(t, err)
+
+	<-time.After(100 * time.Millisecond)
+	fixedDelayTask.Cancel()
+	fixedRateTask.Cancel()
+
+	triggerTypes := make(map[string]int)
+
+	for _, span := range tracer.get() {
+		triggerTypes[span.info.TriggerType]++
+		assert.Nil(t, span.getErr())
+	}
+
+	assert.Equal(t, map[string]int{TriggerTypeFixedDelay: 1, TriggerTypeFixedRate: 1}, triggerTypes)
+}
+
+func TestSimpleTaskScheduler_SetTracer(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewSimpleTaskExecutor(NewDefaultTaskRunner()))
+	tracer := &recordingTracer{}
+	scheduler.SetTracer(tracer)
+
+	task, err := scheduler.ScheduleWithCron(func(ctx context.Context) {
+		execution, _ := ExecutionFromContext(ctx)
+		execution.Fail(errors.New("test error"))
+	}, "* * * * * *", WithName("cron-task"))
+	assert.Nil(t, err)
+
+	<-time.After(1 * time.Second)
+	task.Cancel()
+
+	spans := tracer.get()
+	assert.GreaterOrEqual(t, len(spans), 1)
+	assert.Equal(t, "cron-task", spans[0].info.TaskName)
+	assert.Equal(t, TriggerTypeCron, spans[0].info.TriggerType)
+	assert.Equal(t, "* * * * * *", spans[0].info.CronExpression)
+	assert.Equal(t, "test error", spans[0].getErr().Error())
+}
+
+func TestSimpleTaskScheduler_SetTracer_Retry(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(NewSimpleTaskExecutor(NewDefaultTaskRunner()))
+	defer func() { <-scheduler.Shutdown() }()
+
+	tracer := &recordingTracer{}
+	scheduler.SetTracer(tracer)
+
+	spansInContext := make(chan Span, 2)
+
+	_, err := scheduler.Schedule(func(ctx context.Context) {
+		span, _ := SpanFromContext(ctx)
+		spansInContext <- span
+
+		if execution, _ := ExecutionFromContext(ctx); execution.Attempt() == 1 {
+			execution.Fail(errors.New("temporary failure"))
+		}
+	}, WithName("retried-task"), WithRetry(3, 50*time.Millisecond))
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+
+	spans := tracer.get()
+	assert.Len(t, spans, 2)
+
+	if len(spans) == 2 {
+		assert.Equal(t, spans[0], <-spansInContext)
+		assert.Equal(t, spans[1], <-spansInContext)
+
+		assert.Equal(t, 1, spans[0].info.Attempt)
+		assert.EqualError(t, spans[0].getErr(), "temporary failure")
+		assert.True(t, spans[0].isEnded())
+
+		assert.Equal(t, 2, spans[1].info.Attempt)
+		assert.Equal(t, "retried-task", spans[1].info.TaskName)
+		assert.Nil(t, spans[1].getErr())
+		assert.True(t, spans[1].isEnded())
+	}
+}
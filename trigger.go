@@ -12,6 +12,8 @@ type SimpleTriggerContext struct {
 	lastCompletionTime         time.Time
 	lastExecutionTime          time.Time
 	lastTriggeredExecutionTime time.Time
+	lastManualCompletionTime   time.Time
+	lastManualExecutionTime    time.Time
 }
 
 func NewSimpleTriggerContext() *SimpleTriggerContext {
@@ -24,6 +26,13 @@ func (ctx *SimpleTriggerContext) Update(lastCompletionTime time.Time, lastExecut
 	ctx.lastTriggeredExecutionTime = lastTriggeredExecutionTime
 }
 
+// UpdateManual records a manual execution, which is kept apart from the scheduled executions
+// so that it does not affect the next execution time.
+func (ctx *SimpleTriggerContext) UpdateManual(lastCompletionTime time.Time, lastExecutionTime time.Time) {
+	ctx.lastManualCompletionTime = lastCompletionTime
+	ctx.lastManualExecutionTime = lastExecutionTime
+}
+
 func (ctx *SimpleTriggerContext) LastCompletionTime() time.Time {
 	return ctx.lastCompletionTime
 }
@@ -36,11 +45,22 @@ func (ctx *SimpleTriggerContext) LastTriggeredExecutionTime() time.Time {
 	return ctx.lastTriggeredExecutionTime
 }
 
+func (ctx *SimpleTriggerContext) LastManualCompletionTime() time.Time {
+	return ctx.lastManualCompletionTime
+}
+
+func (ctx *SimpleTriggerContext) LastManualExecutionTime() time.Time {
+	return ctx.lastManualExecutionTime
+}
+
+const maxMissedExecutions = 1000
+
 type Trigger interface {
 	NextExecutionTime(ctx TriggerContext) time.Time
 }
 
 type CronTrigger struct {
+	expression     string
 	cronExpression *CronExpression
 	location       *time.Location
 }
@@ -53,6 +73,7 @@ func CreateCronTrigger(expression string, location *time.Location) (*CronTrigger
 	}
 
 	trigger := &CronTrigger{
+		expression,
 		cron,
 		time.Local,
 	}
@@ -64,6 +85,10 @@ func CreateCronTrigger(expression string, location *time.Location) (*CronTrigger
 	return trigger, nil
 }
 
+func (trigger *CronTrigger) Expression() string {
+	return trigger.expression
+}
+
 func (trigger *CronTrigger) NextExecutionTime(ctx TriggerContext) time.Time {
 	now := time.Now()
 	lastCompletion := ctx.LastCompletionTime()
@@ -78,6 +103,25 @@ func (trigger *CronTrigger) NextExecutionTime(ctx TriggerContext) time.Time {
 
 	}
 
+	return trigger.NextExecutionTimeAfter(now)
+}
+
+// executionTimesBetween returns the execution times starting from the given time
+// (inclusive) until the end time (inclusive).
+func (trigger *CronTrigger) executionTimesBetween(start time.Time, end time.Time) []time.Time {
+	executionTimes := make([]time.Time, 0)
+
+	for next := start; !next.IsZero() && !next.After(end) && len(executionTimes) < maxMissedExecutions; {
+		executionTimes = append(executionTimes, next)
+		next = trigger.NextExecutionTimeAfter(next)
+	}
+
+	return executionTimes
+}
+
+// NextExecutionTimeAfter returns the first execution time after the given time, which is evaluated in the location
+// of the trigger and returned in the location of the given time.
+func (trigger *CronTrigger) NextExecutionTimeAfter(now time.Time) time.Time {
 	originalLocation := now.Location()
 
 	convertedTime := now.In(trigger.location)
@@ -0,0 +1,137 @@
+package chrono
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// DefaultPollInterval is how often a FileWatcher checks its file unless WithPollInterval is used.
+const DefaultPollInterval = 5 * time.Second
+
+type FileWatcherOption func(watcher *FileWatcher) error
+
+// WithPollInterval sets how often the file is checked for changes.
+func WithPollInterval(interval time.Duration) FileWatcherOption {
+	return func(watcher *FileWatcher) error {
+		if interval <= 0 {
+			return errors.New("poll interval must be greater than zero")
+		}
+
+		watcher.interval = interval
+		return nil
+	}
+}
+
+// WithDefinitionParser sets the function parsing the content of the file, which is ParseJobDefinitions by default.
+func WithDefinitionParser(parser func(data []byte) ([]JobDefinition, error)) FileWatcherOption {
+	return func(watcher *FileWatcher) error {
+		if parser == nil {
+			return errors.New("parser cannot be nil")
+		}
+
+		watcher.parser = parser
+		return nil
+	}
+}
+
+// WithReconcileCallback sets the function called after the jobs are reconciled or the file cannot be loaded.
+func WithReconcileCallback(callback func(result ReconcileResult, err error)) FileWatcherOption {
+	return func(watcher *FileWatcher) error {
+		watcher.callback = callback
+		return nil
+	}
+}
+
+// FileWatcher reconciles the jobs with the job definitions in a file whenever the content of the file changes.
+// The file is polled, so that it works on every platform and with the files replaced by renaming.
+type FileWatcher struct {
+	reconciler *JobReconciler
+	path       string
+	interval   time.Duration
+	parser     func(data []byte) ([]JobDefinition, error)
+	callback   func(result ReconcileResult, err error)
+	content    []byte
+	loaded     bool
+}
+
+func NewFileWatcher(reconciler *JobReconciler, path string, options ...FileWatcherOption) (*FileWatcher, error) {
+	if reconciler == nil {
+		return nil, errors.New("reconciler cannot be nil")
+	}
+
+	if path == "" {
+		return nil, errors.New("path cannot be empty")
+	}
+
+	watcher := &FileWatcher{
+		reconciler: reconciler,
+		path:       path,
+		interval:   DefaultPollInterval,
+		parser:     ParseJobDefinitions,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err := option(watcher); err != nil {
+			return nil, err
+		}
+	}
+
+	return watcher, nil
+}
+
+// Watch reconciles the jobs with the file, and then keeps polling the file until the context is cancelled.
+// If the file cannot be read or parsed, the jobs are left as they are until the file is fixed.
+func (watcher *FileWatcher) Watch(ctx context.Context) error {
+	ticker := time.NewTicker(watcher.interval)
+	defer ticker.Stop()
+
+	for {
+		watcher.poll()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (watcher *FileWatcher) poll() {
+	content, err := os.ReadFile(watcher.path)
+
+	if err == nil && watcher.loaded && bytes.Equal(content, watcher.content) {
+		return
+	}
+
+	var (
+		definitions []JobDefinition
+		result      ReconcileResult
+	)
+
+	if err == nil {
+		// the content is not reconciled again until it changes, even if it is invalid
+		watcher.content = content
+		watcher.loaded = true
+
+		if definitions, err = watcher.parser(content); err == nil {
+			result, err = watcher.reconciler.Reconcile(definitions)
+		}
+	}
+
+	if err != nil {
+		watcher.reconciler.scheduler.loggerHolder.get().Error("job definitions could not be reconciled",
+			slog.String("path", watcher.path), slog.Any("error", err))
+	}
+
+	if watcher.callback != nil {
+		watcher.callback(result, err)
+	}
+}
@@ -0,0 +1,141 @@
+package chrono
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type reconcileCall struct {
+	result ReconcileResult
+	err    error
+}
+
+// replaceFile replaces the file by renaming, so that the watcher does not read it while it is being written.
+func replaceFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.WriteFile(path+".tmp", []byte(content), 0o644))
+	assert.Nil(t, os.Rename(path+".tmp", path))
+}
+
+func TestNewFileWatcher(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	_, err = NewFileWatcher(nil, "jobs.json")
+	assert.EqualError(t, err, "reconciler cannot be nil")
+
+	_, err = NewFileWatcher(reconciler, "")
+	assert.EqualError(t, err, "path cannot be empty")
+
+	_, err = NewFileWatcher(reconciler, "jobs.json", WithPollInterval(0))
+	assert.EqualError(t, err, "poll interval must be greater than zero")
+
+	_, err = NewFileWatcher(reconciler, "jobs.json", WithDefinitionParser(nil))
+	assert.EqualError(t, err, "parser cannot be nil")
+
+	watcher, err := NewFileWatcher(reconciler, "jobs.json")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultPollInterval, watcher.interval)
+}
+
+func TestFileWatcher_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{"jobs": [{"name": "job", "task": "task", "fixed_rate": "1h"}]}`), 0o644))
+
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	assert.Nil(t, scheduler.RegisterTask("task", func(ctx context.Context) {}))
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	calls := make(chan reconcileCall, 10)
+	watcher, err := NewFileWatcher(reconciler, path, WithPollInterval(20*time.Millisecond),
+		WithReconcileCallback(func(result ReconcileResult, err error) {
+			calls <- reconcileCall{result, err}
+		}))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		assert.Nil(t, watcher.Watch(ctx))
+	}()
+
+	nextCall := func() reconcileCall {
+		select {
+		case call := <-calls:
+			return call
+		case <-time.After(time.Second):
+			t.Fatal("jobs have not been reconciled")
+			return reconcileCall{}
+		}
+	}
+
+	call := nextCall()
+	assert.Nil(t, call.err)
+	assert.Equal(t, []string{"job"}, call.result.Added)
+
+	task, _ := scheduler.GetTask("job")
+
+	replaceFile(t, path, `{"jobs": [{"name": "job", "task": "task", "fixed_rate": "2h"}]}`)
+
+	call = nextCall()
+	assert.Nil(t, call.err)
+	assert.Equal(t, []string{"job"}, call.result.Rescheduled)
+
+	replaceFile(t, path, `{"jobs": [`)
+
+	call = nextCall()
+	assert.EqualError(t, call.err, "unexpected EOF")
+	assert.False(t, task.IsCancelled())
+
+	<-time.After(100 * time.Millisecond)
+	assert.Empty(t, calls)
+
+	replaceFile(t, path, `{"jobs": []}`)
+
+	call = nextCall()
+	assert.Nil(t, call.err)
+	assert.Equal(t, []string{"job"}, call.result.Removed)
+	assert.True(t, task.IsCancelled())
+
+	cancel()
+	<-done
+}
+
+func TestFileWatcher_WatchMissingFile(t *testing.T) {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	reconciler, err := NewJobReconciler(scheduler)
+	assert.Nil(t, err)
+
+	calls := make(chan reconcileCall, 10)
+	watcher, err := NewFileWatcher(reconciler, filepath.Join(t.TempDir(), "jobs.json"),
+		WithPollInterval(time.Hour),
+		WithReconcileCallback(func(result ReconcileResult, err error) {
+			calls <- reconcileCall{result, err}
+		}))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go watcher.Watch(ctx)
+	defer cancel()
+
+	select {
+	case call := <-calls:
+		assert.ErrorIs(t, call.err, os.ErrNotExist)
+	case <-time.After(time.Second):
+		t.Fatal("watcher has not reported the error")
+	}
+}
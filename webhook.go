@@ -0,0 +1,235 @@
+package chrono
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+)
+
+// maxDiscardedResponseSize is how many bytes of a response body are read before the body is closed,
+// so that the connection can be reused.
+const maxDiscardedResponseSize = 64 * 1024
+
+// WebhookResult is the result of an execution of a webhook task, which is set as the result of the execution.
+type WebhookResult struct {
+	StatusCode int
+	Status     string
+	// Latency is the time passed until the response is received.
+	Latency time.Duration
+}
+
+// WebhookStatusError is the error an execution of a webhook task fails with when the response has
+// a failure status code.
+type WebhookStatusError struct {
+	StatusCode int
+}
+
+func (err *WebhookStatusError) Error() string {
+	return fmt.Sprintf("webhook responded with status code %d", err.StatusCode)
+}
+
+// WebhookData is the data the body template of a webhook task is executed with.
+type WebhookData struct {
+	TaskID        int
+	TaskName      string
+	ScheduledTime time.Time
+	StartTime     time.Time
+	Attempt       int
+}
+
+type WebhookOption func(webhook *webhookTask) error
+
+// WithWebhookHeader adds the header to the request.
+func WithWebhookHeader(name string, value string) WebhookOption {
+	return func(webhook *webhookTask) error {
+		if name == "" {
+			return errors.New("header name cannot be empty")
+		}
+
+		webhook.header.Add(name, value)
+		return nil
+	}
+}
+
+// WithWebhookBody sets the body of the request, which is a text/template executed with WebhookData on each
+// execution, such as `{"scheduled_at": "{{ .ScheduledTime.Format "2006-01-02T15:04:05Z07:00" }}"}`.
+func WithWebhookBody(body string) WebhookOption {
+	return func(webhook *webhookTask) error {
+		bodyTemplate, err := template.New("body").Option("missingkey=error").Parse(body)
+
+		if err != nil {
+			return fmt.Errorf("invalid body template : %w", err)
+		}
+
+		webhook.body = bodyTemplate
+		return nil
+	}
+}
+
+// WithWebhookFailureStatusCodes sets the status codes which fail the execution. The status codes of 400
+// and above are failures by default.
+func WithWebhookFailureStatusCodes(statusCodes ...int) WebhookOption {
+	return func(webhook *webhookTask) error {
+		failureStatusCodes := make(map[int]struct{}, len(statusCodes))
+
+		for _, statusCode := range statusCodes {
+			if statusCode < 100 || statusCode > 999 {
+				return fmt.Errorf("invalid status code : %d", statusCode)
+			}
+
+			failureStatusCodes[statusCode] = struct{}{}
+		}
+
+		webhook.failureStatusCodes = failureStatusCodes
+		return nil
+	}
+}
+
+// WithWebhookClient sets the client sending the requests, which is http.DefaultClient by default.
+func WithWebhookClient(client *http.Client) WebhookOption {
+	return func(webhook *webhookTask) error {
+		if client == nil {
+			return errors.New("client cannot be nil")
+		}
+
+		webhook.client = client
+		return nil
+	}
+}
+
+type webhookTask struct {
+	method             string
+	url                string
+	header             http.Header
+	body               *template.Template
+	failureStatusCodes map[int]struct{}
+	client             *http.Client
+}
+
+// NewWebhookTask returns a task sending an HTTP request with the method to the URL. The request is cancelled
+// once the context of the execution is cancelled. The status and the latency of the response are set as the
+// WebhookResult of the execution, and the execution fails with a WebhookStatusError if the response has
+// a failure status code.
+func NewWebhookTask(method string, rawURL string, options ...WebhookOption) (Task, error) {
+	if method == "" {
+		return nil, errors.New("method cannot be empty")
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid url : %w", err)
+	}
+
+	if (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, fmt.Errorf("invalid url : %s", rawURL)
+	}
+
+	webhook := &webhookTask{
+		method: method,
+		url:    rawURL,
+		header: make(http.Header),
+		client: http.DefaultClient,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err = option(webhook); err != nil {
+			return nil, err
+		}
+	}
+
+	return webhook.run, nil
+}
+
+func (webhook *webhookTask) run(ctx context.Context) {
+	execution, ok := ExecutionFromContext(ctx)
+
+	if !ok {
+		execution = &Execution{scheduledTime: time.Now(), startTime: time.Now(), attempt: 1}
+	}
+
+	result, err := webhook.send(ctx, execution)
+
+	if result.StatusCode != 0 {
+		execution.SetResult(result)
+	}
+
+	switch {
+	case err == nil:
+	case ctx.Err() != nil:
+		execution.Fail(fmt.Errorf("webhook request is cancelled : %w", context.Cause(ctx)))
+	default:
+		execution.Fail(err)
+	}
+}
+
+func (webhook *webhookTask) send(ctx context.Context, execution *Execution) (WebhookResult, error) {
+	var body io.Reader
+
+	if webhook.body != nil {
+		var buffer bytes.Buffer
+
+		err := webhook.body.Execute(&buffer, WebhookData{
+			TaskID:        execution.TaskID(),
+			TaskName:      execution.TaskName(),
+			ScheduledTime: execution.ScheduledTime(),
+			StartTime:     execution.StartTime(),
+			Attempt:       execution.Attempt(),
+		})
+
+		if err != nil {
+			return WebhookResult{}, fmt.Errorf("body could not be created : %w", err)
+		}
+
+		body = &buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, webhook.method, webhook.url, body)
+
+	if err != nil {
+		return WebhookResult{}, err
+	}
+
+	request.Header = webhook.header.Clone()
+
+	start := time.Now()
+	response, err := webhook.client.Do(request)
+
+	if err != nil {
+		return WebhookResult{}, err
+	}
+
+	result := WebhookResult{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Latency:    time.Since(start),
+	}
+
+	io.Copy(io.Discard, io.LimitReader(response.Body, maxDiscardedResponseSize))
+	response.Body.Close()
+
+	if webhook.isFailure(response.StatusCode) {
+		return result, &WebhookStatusError{StatusCode: response.StatusCode}
+	}
+
+	return result, nil
+}
+
+func (webhook *webhookTask) isFailure(statusCode int) bool {
+	if webhook.failureStatusCodes == nil {
+		return statusCode >= http.StatusBadRequest
+	}
+
+	_, ok := webhook.failureStatusCodes[statusCode]
+	return ok
+}
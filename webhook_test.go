@@ -0,0 +1,169 @@
+package chrono
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func runWebhookTask(t *testing.T, webhook Task, options ...Option) ExecutionRecord {
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	task, err := scheduler.Schedule(webhook, options...)
+	assert.Nil(t, err)
+
+	for i := 0; i < 50; i++ {
+		<-time.After(100 * time.Millisecond)
+
+		if history := task.(HistoryProvider).History(); len(history) != 0 {
+			return history[0]
+		}
+	}
+
+	t.Fatal("webhook has not been called")
+	return ExecutionRecord{}
+}
+
+func TestNewWebhookTask(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan string, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- string(body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	webhook, err := NewWebhookTask(http.MethodPost, server.URL+"/jobs",
+		WithWebhookHeader("Content-Type", "application/json"),
+		WithWebhookHeader("Authorization", "Bearer token"),
+		WithWebhookBody(`{"attempt": {{ .Attempt }}, "scheduled_at": "{{ .ScheduledTime.UTC.Format "2006-01-02T15:04:05Z07:00" }}"}`),
+	)
+	assert.Nil(t, err)
+
+	startTime := time.Now().Add(100 * time.Millisecond).Truncate(time.Second).Add(time.Second)
+	record := runWebhookTask(t, webhook, WithTime(startTime))
+
+	request := <-requests
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, "/jobs", request.URL.Path)
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+	assert.Equal(t, `{"attempt": 1, "scheduled_at": "`+startTime.UTC().Format(time.RFC3339)+`"}`, <-bodies)
+
+	assert.Equal(t, ExecutionSucceeded, record.Outcome)
+	assert.Nil(t, record.Err)
+
+	result := record.Result.(WebhookResult)
+	assert.Equal(t, http.StatusAccepted, result.StatusCode)
+	assert.Equal(t, "202 Accepted", result.Status)
+	assert.Greater(t, result.Latency, time.Duration(0))
+}
+
+func TestNewWebhookTask_InvalidOptions(t *testing.T) {
+	_, err := NewWebhookTask("", "http://localhost")
+	assert.EqualError(t, err, "method cannot be empty")
+
+	_, err = NewWebhookTask(http.MethodGet, "localhost/jobs")
+	assert.EqualError(t, err, "invalid url : localhost/jobs")
+
+	_, err = NewWebhookTask(http.MethodGet, "http://localhost", WithWebhookHeader("", "value"))
+	assert.EqualError(t, err, "header name cannot be empty")
+
+	_, err = NewWebhookTask(http.MethodGet, "http://localhost", WithWebhookBody("{{ .ScheduledTime"))
+	assert.Contains(t, err.Error(), "invalid body template : ")
+
+	_, err = NewWebhookTask(http.MethodGet, "http://localhost", WithWebhookFailureStatusCodes(42))
+	assert.EqualError(t, err, "invalid status code : 42")
+
+	_, err = NewWebhookTask(http.MethodGet, "http://localhost", WithWebhookClient(nil))
+	assert.EqualError(t, err, "client cannot be nil")
+
+	_, err = NewWebhookTask(http.MethodGet, "https://localhost", nil)
+	assert.Nil(t, err)
+}
+
+func TestNewWebhookTask_FailureStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhook, err := NewWebhookTask(http.MethodGet, server.URL)
+	assert.Nil(t, err)
+
+	record := runWebhookTask(t, webhook)
+
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.Equal(t, &WebhookStatusError{StatusCode: http.StatusServiceUnavailable}, record.Err)
+	assert.EqualError(t, record.Err, "webhook responded with status code 503")
+	assert.Equal(t, http.StatusServiceUnavailable, record.Result.(WebhookResult).StatusCode)
+}
+
+func TestNewWebhookTask_CustomFailureStatusCodes(t *testing.T) {
+	statusCodes := make(chan int, 2)
+	statusCodes <- http.StatusNotFound
+	statusCodes <- http.StatusNoContent
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(<-statusCodes)
+	}))
+	defer server.Close()
+
+	webhook, err := NewWebhookTask(http.MethodDelete, server.URL, WithWebhookFailureStatusCodes(http.StatusNoContent))
+	assert.Nil(t, err)
+
+	record := runWebhookTask(t, webhook)
+	assert.Equal(t, ExecutionSucceeded, record.Outcome)
+	assert.Equal(t, http.StatusNotFound, record.Result.(WebhookResult).StatusCode)
+
+	record = runWebhookTask(t, webhook)
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.EqualError(t, record.Err, "webhook responded with status code 204")
+}
+
+func TestNewWebhookTask_Timeout(t *testing.T) {
+	done := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	webhook, err := NewWebhookTask(http.MethodGet, server.URL)
+	assert.Nil(t, err)
+
+	start := time.Now()
+	record := runWebhookTask(t, webhook, WithTimeout(200*time.Millisecond))
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.True(t, errors.Is(record.Err, ErrExecutionTimeout))
+	assert.EqualError(t, record.Err, "webhook request is cancelled : "+ErrExecutionTimeout.Error())
+	assert.Nil(t, record.Result)
+}
+
+func TestNewWebhookTask_ConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	webhook, err := NewWebhookTask(http.MethodGet, server.URL)
+	assert.Nil(t, err)
+
+	record := runWebhookTask(t, webhook)
+
+	assert.Equal(t, ExecutionFailed, record.Outcome)
+	assert.Contains(t, record.Err.Error(), "connection refused")
+	assert.Nil(t, record.Result)
+}
@@ -0,0 +1,530 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NodeCondition decides whether a node of a workflow runs depending on how its upstreams are completed.
+type NodeCondition int
+
+const (
+	// RunOnSuccess runs the node if all of its upstreams succeed. The node is marked as upstream failed
+	// if any of them fails, so that the failure is propagated to its downstreams.
+	RunOnSuccess NodeCondition = iota
+	// RunOnFailure runs the node if any of its upstreams fails, and skips it otherwise.
+	RunOnFailure
+	// RunAlways runs the node once all of its upstreams are completed in any way.
+	RunAlways
+)
+
+type NodeStatus int
+
+const (
+	NodePending NodeStatus = iota
+	NodeRunning
+	NodeSucceeded
+	NodeFailed
+	NodeSkipped
+	NodeUpstreamFailed
+	// NodeCancelled is the status of the nodes which are not run since the context of the workflow run is cancelled.
+	NodeCancelled
+)
+
+func (status NodeStatus) String() string {
+	switch status {
+	case NodePending:
+		return "pending"
+	case NodeRunning:
+		return "running"
+	case NodeSucceeded:
+		return "succeeded"
+	case NodeFailed:
+		return "failed"
+	case NodeSkipped:
+		return "skipped"
+	case NodeUpstreamFailed:
+		return "upstream failed"
+	case NodeCancelled:
+		return "cancelled"
+	}
+
+	return "unknown"
+}
+
+// failed reports whether the node or any of its upstreams has failed.
+func (status NodeStatus) failed() bool {
+	return status == NodeFailed || status == NodeUpstreamFailed
+}
+
+type WorkflowStatus int
+
+const (
+	WorkflowRunning WorkflowStatus = iota
+	WorkflowSucceeded
+	WorkflowFailed
+)
+
+func (status WorkflowStatus) String() string {
+	switch status {
+	case WorkflowRunning:
+		return "running"
+	case WorkflowSucceeded:
+		return "succeeded"
+	case WorkflowFailed:
+		return "failed"
+	}
+
+	return "unknown"
+}
+
+// WorkflowNode is a task in a workflow, which runs after its upstreams are completed.
+type WorkflowNode struct {
+	Name      string
+	Task      Task
+	Upstreams []string
+	Condition NodeCondition
+}
+
+// NodeRun is the status of a node in a workflow run.
+type NodeRun struct {
+	Name      string
+	Status    NodeStatus
+	StartTime time.Time
+	EndTime   time.Time
+	Err       error
+	Result    interface{}
+}
+
+// WorkflowRun is the status of a run of a workflow, whose nodes are in the order they are defined.
+type WorkflowRun struct {
+	ID            int
+	ScheduledTime time.Time
+	StartTime     time.Time
+	EndTime       time.Time
+	Status        WorkflowStatus
+	Err           error
+	Nodes         []NodeRun
+}
+
+// Node returns the status of the named node in the run.
+func (run WorkflowRun) Node(name string) (NodeRun, bool) {
+	for _, node := range run.Nodes {
+		if node.Name == name {
+			return node, true
+		}
+	}
+
+	return NodeRun{}, false
+}
+
+type WorkflowOption func(workflow *Workflow) error
+
+// WithWorkflowParallelism sets how many nodes of a run can be running at the same time.
+// The nodes are not limited by default.
+func WithWorkflowParallelism(parallelism int) WorkflowOption {
+	return func(workflow *Workflow) error {
+		if parallelism < 0 {
+			return errors.New("parallelism cannot be negative")
+		}
+
+		workflow.parallelism = parallelism
+		return nil
+	}
+}
+
+// WithWorkflowHistorySize sets how many of the last runs of a workflow are kept.
+func WithWorkflowHistorySize(size int) WorkflowOption {
+	return func(workflow *Workflow) error {
+		if size < 0 {
+			return errors.New("history size cannot be negative")
+		}
+
+		workflow.historySize = size
+		return nil
+	}
+}
+
+// Workflow is a directed acyclic graph of tasks. Each run of the workflow is an execution of its task,
+// which can be scheduled like any other task.
+type Workflow struct {
+	nodes       []WorkflowNode
+	downstreams [][]int
+	upstreams   [][]int
+	parallelism int
+	historySize int
+	lastRunID   int
+	runs        []*WorkflowRun
+	runsMu      sync.RWMutex
+}
+
+// NewWorkflow validates the nodes, and returns a workflow running them.
+func NewWorkflow(nodes []WorkflowNode, options ...WorkflowOption) (*Workflow, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("nodes cannot be empty")
+	}
+
+	workflow := &Workflow{
+		nodes:       append([]WorkflowNode{}, nodes...),
+		downstreams: make([][]int, len(nodes)),
+		upstreams:   make([][]int, len(nodes)),
+		historySize: DefaultHistorySize,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if err := option(workflow); err != nil {
+			return nil, err
+		}
+	}
+
+	indexes := make(map[string]int, len(nodes))
+
+	for index, node := range nodes {
+		if node.Name == "" {
+			return nil, errors.New("node name cannot be empty")
+		}
+
+		if _, ok := indexes[node.Name]; ok {
+			return nil, fmt.Errorf("duplicate node name : %s", node.Name)
+		}
+
+		indexes[node.Name] = index
+	}
+
+	for index, node := range nodes {
+		if node.Task == nil {
+			return nil, fmt.Errorf("node %s : task cannot be nil", node.Name)
+		}
+
+		if node.Condition < RunOnSuccess || node.Condition > RunAlways {
+			return nil, fmt.Errorf("node %s : invalid condition", node.Name)
+		}
+
+		for _, upstream := range node.Upstreams {
+			upstreamIndex, ok := indexes[upstream]
+
+			if !ok {
+				return nil, fmt.Errorf("node %s : no node found with name %s", node.Name, upstream)
+			}
+
+			workflow.upstreams[index] = append(workflow.upstreams[index], upstreamIndex)
+			workflow.downstreams[upstreamIndex] = append(workflow.downstreams[upstreamIndex], index)
+		}
+	}
+
+	if cycle := workflow.cycle(); len(cycle) != 0 {
+		return nil, fmt.Errorf("workflow has a cycle between nodes %s", strings.Join(cycle, ", "))
+	}
+
+	return workflow, nil
+}
+
+// cycle returns the names of the nodes which cannot be ordered topologically since they are in a cycle.
+func (workflow *Workflow) cycle() []string {
+	pending := make([]int, len(workflow.nodes))
+	var ready []int
+
+	for index := range workflow.nodes {
+		if pending[index] = len(workflow.upstreams[index]); pending[index] == 0 {
+			ready = append(ready, index)
+		}
+	}
+
+	for len(ready) != 0 {
+		index := ready[0]
+		ready = ready[1:]
+
+		for _, downstream := range workflow.downstreams[index] {
+			if pending[downstream]--; pending[downstream] == 0 {
+				ready = append(ready, downstream)
+			}
+		}
+	}
+
+	var cycle []string
+
+	for index, node := range workflow.nodes {
+		if pending[index] != 0 {
+			cycle = append(cycle, node.Name)
+		}
+	}
+
+	sort.Strings(cycle)
+	return cycle
+}
+
+// Task returns the task running the workflow. The execution fails if any of the nodes fails, and
+// the WorkflowRun is set as its result.
+func (workflow *Workflow) Task() Task {
+	return workflow.run
+}
+
+// Runs returns the last runs of the workflow including the running ones, ordered from the oldest to the newest.
+func (workflow *Workflow) Runs() []WorkflowRun {
+	workflow.runsMu.RLock()
+	defer workflow.runsMu.RUnlock()
+
+	runs := make([]WorkflowRun, len(workflow.runs))
+
+	for index, run := range workflow.runs {
+		runs[index] = copyWorkflowRun(run)
+	}
+
+	return runs
+}
+
+// GetRun returns the run with the given id if it is still kept.
+func (workflow *Workflow) GetRun(id int) (WorkflowRun, bool) {
+	workflow.runsMu.RLock()
+	defer workflow.runsMu.RUnlock()
+
+	for _, run := range workflow.runs {
+		if run.ID == id {
+			return copyWorkflowRun(run), true
+		}
+	}
+
+	return WorkflowRun{}, false
+}
+
+// LastRun returns the latest run of the workflow.
+func (workflow *Workflow) LastRun() (WorkflowRun, bool) {
+	workflow.runsMu.RLock()
+	defer workflow.runsMu.RUnlock()
+
+	if len(workflow.runs) == 0 {
+		return WorkflowRun{}, false
+	}
+
+	return copyWorkflowRun(workflow.runs[len(workflow.runs)-1]), true
+}
+
+func copyWorkflowRun(run *WorkflowRun) WorkflowRun {
+	copied := *run
+	copied.Nodes = append([]NodeRun{}, run.Nodes...)
+	return copied
+}
+
+func (workflow *Workflow) startRun(scheduledTime time.Time) *WorkflowRun {
+	workflow.runsMu.Lock()
+	defer workflow.runsMu.Unlock()
+
+	workflow.lastRunID++
+
+	run := &WorkflowRun{
+		ID:            workflow.lastRunID,
+		ScheduledTime: scheduledTime,
+		StartTime:     time.Now(),
+		Status:        WorkflowRunning,
+		Nodes:         make([]NodeRun, len(workflow.nodes)),
+	}
+
+	for index, node := range workflow.nodes {
+		run.Nodes[index] = NodeRun{Name: node.Name, Status: NodePending}
+	}
+
+	workflow.runs = append(workflow.runs, run)
+
+	if len(workflow.runs) > workflow.historySize {
+		workflow.runs = append([]*WorkflowRun{}, workflow.runs[len(workflow.runs)-workflow.historySize:]...)
+	}
+
+	return run
+}
+
+// updateNode updates the status of a node in the run, which might be read by the queries at the same time.
+func (workflow *Workflow) updateNode(run *WorkflowRun, index int, update func(node *NodeRun)) {
+	workflow.runsMu.Lock()
+	defer workflow.runsMu.Unlock()
+	update(&run.Nodes[index])
+}
+
+func (workflow *Workflow) run(ctx context.Context) {
+	execution, ok := ExecutionFromContext(ctx)
+
+	if !ok {
+		execution = &Execution{scheduledTime: time.Now(), startTime: time.Now(), attempt: 1}
+	}
+
+	run := workflow.startRun(execution.ScheduledTime())
+	workflow.runNodes(ctx, execution, run)
+
+	workflow.runsMu.Lock()
+
+	var errs []error
+
+	for _, node := range run.Nodes {
+		if node.Status == NodeFailed {
+			errs = append(errs, fmt.Errorf("node %s : %w", node.Name, node.Err))
+		}
+	}
+
+	if ctx.Err() != nil {
+		errs = append(errs, fmt.Errorf("workflow is cancelled : %w", context.Cause(ctx)))
+	}
+
+	run.EndTime = time.Now()
+	run.Status = WorkflowSucceeded
+
+	if len(errs) != 0 {
+		run.Status = WorkflowFailed
+		run.Err = errors.Join(errs...)
+	}
+
+	result := copyWorkflowRun(run)
+	workflow.runsMu.Unlock()
+
+	execution.SetResult(result)
+	execution.Fail(result.Err)
+}
+
+// runNodes runs each node once its upstreams are completed, keeping at most as many nodes running as the parallelism.
+func (workflow *Workflow) runNodes(ctx context.Context, execution *Execution, run *WorkflowRun) {
+	pending := make([]int, len(workflow.nodes))
+	statuses := make([]NodeStatus, len(workflow.nodes))
+	completed := make(chan int)
+
+	var ready []int
+
+	for index := range workflow.nodes {
+		if pending[index] = len(workflow.upstreams[index]); pending[index] == 0 {
+			ready = append(ready, index)
+		}
+	}
+
+	complete := func(index int) {
+		for _, downstream := range workflow.downstreams[index] {
+			if pending[downstream]--; pending[downstream] == 0 {
+				ready = append(ready, downstream)
+			}
+		}
+	}
+
+	remaining := len(workflow.nodes)
+	running := 0
+
+	for remaining != 0 {
+		var waiting []int
+
+		for len(ready) != 0 {
+			index := ready[0]
+			ready = ready[1:]
+
+			status, ok := workflow.condition(index, statuses)
+
+			if ok && ctx.Err() != nil {
+				status, ok = NodeCancelled, false
+			}
+
+			if !ok {
+				statuses[index] = status
+				workflow.updateNode(run, index, func(node *NodeRun) {
+					node.Status = status
+				})
+
+				remaining--
+				complete(index)
+				continue
+			}
+
+			if workflow.parallelism != 0 && running >= workflow.parallelism {
+				waiting = append(waiting, index)
+				continue
+			}
+
+			running++
+			statuses[index] = NodeRunning
+
+			go func(index int) {
+				workflow.runNode(ctx, execution, run, index)
+				completed <- index
+			}(index)
+		}
+
+		ready = waiting
+
+		if remaining == 0 {
+			break
+		}
+
+		index := <-completed
+		running--
+		remaining--
+
+		workflow.runsMu.RLock()
+		statuses[index] = run.Nodes[index].Status
+		workflow.runsMu.RUnlock()
+
+		complete(index)
+	}
+}
+
+// condition returns whether the node can run, or the status it is marked with otherwise.
+func (workflow *Workflow) condition(index int, statuses []NodeStatus) (NodeStatus, bool) {
+	failed, succeeded := 0, 0
+
+	for _, upstream := range workflow.upstreams[index] {
+		if statuses[upstream].failed() {
+			failed++
+		} else if statuses[upstream] == NodeSucceeded {
+			succeeded++
+		}
+	}
+
+	switch workflow.nodes[index].Condition {
+	case RunOnFailure:
+		if failed == 0 {
+			return NodeSkipped, false
+		}
+	case RunOnSuccess:
+		if failed != 0 {
+			return NodeUpstreamFailed, false
+		}
+
+		if succeeded != len(workflow.upstreams[index]) {
+			return NodeSkipped, false
+		}
+	}
+
+	return NodeRunning, true
+}
+
+func (workflow *Workflow) runNode(ctx context.Context, execution *Execution, run *WorkflowRun, index int) {
+	node := workflow.nodes[index]
+
+	nodeExecution := &Execution{
+		taskID:        execution.TaskID(),
+		taskName:      node.Name,
+		scheduledTime: execution.ScheduledTime(),
+		startTime:     time.Now(),
+		attempt:       1,
+		manual:        execution.Manual(),
+	}
+
+	workflow.updateNode(run, index, func(nodeRun *NodeRun) {
+		nodeRun.Status = NodeRunning
+		nodeRun.StartTime = nodeExecution.StartTime()
+	})
+
+	nodeExecution.run(ctx, node.Task)
+
+	workflow.updateNode(run, index, func(nodeRun *NodeRun) {
+		nodeRun.EndTime = time.Now()
+		nodeRun.Err = nodeExecution.Err()
+		nodeRun.Result = nodeExecution.Result()
+		nodeRun.Status = NodeSucceeded
+
+		if nodeRun.Err != nil {
+			nodeRun.Status = NodeFailed
+		}
+	})
+}
@@ -0,0 +1,301 @@
+package chrono
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type workflowRecorder struct {
+	order   []string
+	orderMu sync.Mutex
+}
+
+func (recorder *workflowRecorder) task(name string, err error) Task {
+	return func(ctx context.Context) {
+		recorder.orderMu.Lock()
+		recorder.order = append(recorder.order, name)
+		recorder.orderMu.Unlock()
+
+		execution, _ := ExecutionFromContext(ctx)
+		execution.SetResult(name)
+		execution.Fail(err)
+	}
+}
+
+func (recorder *workflowRecorder) get() []string {
+	recorder.orderMu.Lock()
+	defer recorder.orderMu.Unlock()
+	return append([]string{}, recorder.order...)
+}
+
+func nodeStatuses(run WorkflowRun) map[string]NodeStatus {
+	statuses := make(map[string]NodeStatus, len(run.Nodes))
+
+	for _, node := range run.Nodes {
+		statuses[node.Name] = node.Status
+	}
+
+	return statuses
+}
+
+func TestNewWorkflow_InvalidNodes(t *testing.T) {
+	task := func(ctx context.Context) {}
+
+	testCases := []struct {
+		nodes []WorkflowNode
+		err   string
+	}{
+		{nil, "nodes cannot be empty"},
+		{[]WorkflowNode{{Task: task}}, "node name cannot be empty"},
+		{[]WorkflowNode{{Name: "a", Task: task}, {Name: "a", Task: task}}, "duplicate node name : a"},
+		{[]WorkflowNode{{Name: "a"}}, "node a : task cannot be nil"},
+		{[]WorkflowNode{{Name: "a", Task: task, Condition: 5}}, "node a : invalid condition"},
+		{[]WorkflowNode{{Name: "a", Task: task, Upstreams: []string{"b"}}}, "node a : no node found with name b"},
+		{[]WorkflowNode{
+			{Name: "a", Task: task},
+			{Name: "b", Task: task, Upstreams: []string{"a", "d"}},
+			{Name: "c", Task: task, Upstreams: []string{"b"}},
+			{Name: "d", Task: task, Upstreams: []string{"c"}},
+		}, "workflow has a cycle between nodes b, c, d"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := NewWorkflow(testCase.nodes)
+		assert.EqualError(t, err, testCase.err)
+	}
+
+	_, err := NewWorkflow([]WorkflowNode{{Name: "a", Task: task}}, WithWorkflowParallelism(-1))
+	assert.EqualError(t, err, "parallelism cannot be negative")
+
+	_, err = NewWorkflow([]WorkflowNode{{Name: "a", Task: task}}, WithWorkflowHistorySize(-1))
+	assert.EqualError(t, err, "history size cannot be negative")
+}
+
+func TestWorkflow_Task(t *testing.T) {
+	recorder := &workflowRecorder{}
+
+	workflow, err := NewWorkflow([]WorkflowNode{
+		{Name: "load", Task: recorder.task("load", nil), Upstreams: []string{"extract", "transform"}},
+		{Name: "extract", Task: recorder.task("extract", nil)},
+		{Name: "transform", Task: recorder.task("transform", nil), Upstreams: []string{"extract"}},
+		{Name: "alert", Task: recorder.task("alert", nil), Upstreams: []string{"load"}, Condition: RunOnFailure},
+		{Name: "cleanup", Task: recorder.task("cleanup", nil), Upstreams: []string{"load"}, Condition: RunAlways},
+	})
+	assert.Nil(t, err)
+
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	task, err := scheduler.Schedule(workflow.Task())
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.Equal(t, []string{"extract", "transform", "load", "cleanup"}, recorder.get())
+
+	run, ok := workflow.LastRun()
+	assert.True(t, ok)
+	assert.Equal(t, 1, run.ID)
+	assert.Equal(t, WorkflowSucceeded, run.Status)
+	assert.Nil(t, run.Err)
+	assert.False(t, run.EndTime.IsZero())
+	assert.Equal(t, map[string]NodeStatus{
+		"load":      NodeSucceeded,
+		"extract":   NodeSucceeded,
+		"transform": NodeSucceeded,
+		"alert":     NodeSkipped,
+		"cleanup":   NodeSucceeded,
+	}, nodeStatuses(run))
+
+	node, ok := run.Node("load")
+	assert.True(t, ok)
+	assert.Equal(t, "load", node.Result)
+	assert.False(t, node.StartTime.IsZero())
+
+	history := task.(HistoryProvider).History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, ExecutionSucceeded, history[0].Outcome)
+	assert.Equal(t, run, history[0].Result)
+}
+
+func TestWorkflow_TaskWithFailure(t *testing.T) {
+	recorder := &workflowRecorder{}
+	failure := errors.New("connection refused")
+
+	workflow, err := NewWorkflow([]WorkflowNode{
+		{Name: "extract", Task: recorder.task("extract", failure)},
+		{Name: "transform", Task: recorder.task("transform", nil), Upstreams: []string{"extract"}},
+		{Name: "load", Task: recorder.task("load", nil), Upstreams: []string{"transform"}},
+		{Name: "alert", Task: recorder.task("alert", nil), Upstreams: []string{"load"}, Condition: RunOnFailure},
+		{Name: "cleanup", Task: recorder.task("cleanup", nil), Upstreams: []string{"load"}, Condition: RunAlways},
+		{Name: "report", Task: recorder.task("report", nil), Upstreams: []string{"alert"}},
+	})
+	assert.Nil(t, err)
+
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	task, err := scheduler.Schedule(workflow.Task())
+	assert.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+
+	assert.ElementsMatch(t, []string{"extract", "alert", "cleanup", "report"}, recorder.get())
+
+	run, ok := workflow.LastRun()
+	assert.True(t, ok)
+	assert.Equal(t, WorkflowFailed, run.Status)
+	assert.True(t, errors.Is(run.Err, failure))
+	assert.EqualError(t, run.Err, "node extract : connection refused")
+	assert.Equal(t, map[string]NodeStatus{
+		"extract":   NodeFailed,
+		"transform": NodeUpstreamFailed,
+		"load":      NodeUpstreamFailed,
+		"alert":     NodeSucceeded,
+		"cleanup":   NodeSucceeded,
+		"report":    NodeSucceeded,
+	}, nodeStatuses(run))
+
+	history := task.(HistoryProvider).History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, ExecutionFailed, history[0].Outcome)
+	assert.Equal(t, run.Err, history[0].Err)
+}
+
+func TestWorkflow_TaskWithPanic(t *testing.T) {
+	workflow, err := NewWorkflow([]WorkflowNode{
+		{Name: "extract", Task: func(ctx context.Context) { panic("unexpected") }},
+	})
+	assert.Nil(t, err)
+
+	workflow.Task()(context.Background())
+
+	run, ok := workflow.LastRun()
+	assert.True(t, ok)
+	assert.Equal(t, WorkflowFailed, run.Status)
+	assert.EqualError(t, run.Err, "node extract : task panicked : unexpected")
+}
+
+func TestWorkflow_TaskWithParallelism(t *testing.T) {
+	var running, maxRunning int32
+
+	task := func(ctx context.Context) {
+		current := atomic.AddInt32(&running, 1)
+
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+
+		<-time.After(50 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}
+
+	nodes := []WorkflowNode{{Name: "start", Task: task}}
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		nodes = append(nodes, WorkflowNode{Name: name, Task: task, Upstreams: []string{"start"}})
+	}
+
+	workflow, err := NewWorkflow(nodes, WithWorkflowParallelism(2))
+	assert.Nil(t, err)
+
+	start := time.Now()
+	workflow.Task()(context.Background())
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	run, _ := workflow.LastRun()
+	assert.Equal(t, WorkflowSucceeded, run.Status)
+}
+
+func TestWorkflow_TaskWithTimeout(t *testing.T) {
+	recorder := &workflowRecorder{}
+
+	workflow, err := NewWorkflow([]WorkflowNode{
+		{Name: "extract", Task: func(ctx context.Context) { <-ctx.Done() }},
+		{Name: "load", Task: recorder.task("load", nil), Upstreams: []string{"extract"}, Condition: RunAlways},
+	})
+	assert.Nil(t, err)
+
+	scheduler := NewSimpleTaskScheduler(nil)
+	defer func() { <-scheduler.Shutdown() }()
+
+	task, err := scheduler.Schedule(workflow.Task(), WithTimeout(100*time.Millisecond))
+	assert.Nil(t, err)
+
+	<-time.After(300 * time.Millisecond)
+
+	assert.Empty(t, recorder.get())
+
+	run, _ := workflow.LastRun()
+	assert.Equal(t, WorkflowFailed, run.Status)
+	assert.True(t, errors.Is(run.Err, ErrExecutionTimeout))
+	assert.Equal(t, map[string]NodeStatus{
+		"extract": NodeSucceeded,
+		"load":    NodeCancelled,
+	}, nodeStatuses(run))
+
+	history := task.(HistoryProvider).History()
+	assert.Len(t, history, 1)
+	assert.True(t, errors.Is(history[0].Err, ErrExecutionTimeout))
+}
+
+func TestWorkflow_Runs(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	workflow, err := NewWorkflow([]WorkflowNode{
+		{Name: "extract", Task: func(ctx context.Context) {
+			started <- struct{}{}
+			<-release
+		}},
+	}, WithWorkflowHistorySize(2))
+	assert.Nil(t, err)
+
+	_, ok := workflow.LastRun()
+	assert.False(t, ok)
+
+	for i := 0; i < 3; i++ {
+		done := make(chan struct{})
+
+		go func() {
+			workflow.Task()(context.Background())
+			close(done)
+		}()
+
+		<-started
+
+		run, ok := workflow.LastRun()
+		assert.True(t, ok)
+		assert.Equal(t, i+1, run.ID)
+		assert.Equal(t, WorkflowRunning, run.Status)
+		assert.Equal(t, NodeRunning, run.Nodes[0].Status)
+
+		release <- struct{}{}
+		<-done
+	}
+
+	runs := workflow.Runs()
+	assert.Len(t, runs, 2)
+	assert.Equal(t, 2, runs[0].ID)
+	assert.Equal(t, 3, runs[1].ID)
+	assert.Equal(t, WorkflowSucceeded, runs[0].Status)
+	assert.Equal(t, NodeSucceeded, runs[0].Nodes[0].Status)
+
+	_, ok = workflow.GetRun(1)
+	assert.False(t, ok)
+
+	run, ok := workflow.GetRun(2)
+	assert.True(t, ok)
+	assert.Equal(t, runs[0], run)
+}